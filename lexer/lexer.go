@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"io"
 	"log"
+	"strings"
 )
 
 // runeReader represents behavior for reading and unreading runes.
@@ -13,24 +14,53 @@ type runeReader interface {
 	UnreadRune() error
 }
 
+// Position represents a location in the input. Line and Column start at 1
+// and Column counts runes, while Offset is the number of bytes before the location.
+type Position struct {
+	Line, Column, Offset int
+}
+
 // Scanner represents our main "reading" implementation for reading and storing runes.
 type Scanner struct {
 	r runeReader
 	b *bytes.Buffer
+
+	pos    Position // Position of the next rune to be read.
+	prev   Position // Position before the last read rune, restored on unread.
+	tokPos Position // Position of the first rune of the last scanned token.
+
+	nonFinite bool // Whether 'inf' and 'nan' are scanned as 'FLOAT'.
+}
+
+// Option represents a configuration applied to a 'Scanner' on creation.
+type Option func(*Scanner)
+
+// WithNonFinite will make the scanner accept 'inf', 'infinity' and 'nan',
+// optionally signed and in any case, as 'FLOAT' tokens.
+func WithNonFinite() Option {
+	return func(s *Scanner) {
+		s.nonFinite = true
+	}
 }
 
 // NewScanner returns a pointer to a 'Scanner' with an instantiated
 // reader and buffer.
-func NewScanner(r io.Reader) *Scanner {
-	return &Scanner{
-		r: bufio.NewReader(r),
-		b: new(bytes.Buffer),
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	s := &Scanner{
+		r:   bufio.NewReader(r),
+		b:   new(bytes.Buffer),
+		pos: Position{Line: 1, Column: 1},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Scan will read from our input and return the corresponding token and string value.
 func (s *Scanner) Scan() (Token, string) {
 	// Read the next rune.
+	s.tokPos = s.pos
 	r := s.read()
 
 	// If we catch any whitespace then consume it until we find next token.
@@ -45,8 +75,8 @@ func (s *Scanner) Scan() (Token, string) {
 		return s.scanString()
 	}
 
-	// If we catch any integer then consume as integer or number (floating point values).
-	if isInteger(r) {
+	// If we catch any integer, sign or period then consume as integer or number (floating point values).
+	if isInteger(r) || isSign(r) || isPeriod(r) {
 		s.unread()
 		return s.scanNumber()
 	}
@@ -57,7 +87,7 @@ func (s *Scanner) Scan() (Token, string) {
 		val string
 	)
 	switch r {
-	case 0:
+	case rune(EOF):
 		tok = EOF
 	case '\n':
 		tok = NEWLINE
@@ -69,17 +99,34 @@ func (s *Scanner) Scan() (Token, string) {
 	return tok, val
 }
 
+// Pos returns the position of the first rune of the last scanned token.
+func (s *Scanner) Pos() Position {
+	return s.tokPos
+}
+
 func (s *Scanner) read() rune {
-	r, _, err := s.r.ReadRune()
+	s.prev = s.pos
+	r, size, err := s.r.ReadRune()
 	if err != nil {
-		log.Printf("lexer: error reading rune [%s]", err)
+		if err != io.EOF {
+			log.Printf("lexer: error reading rune [%s]", err)
+		}
 		return rune(EOF)
 	}
+
+	s.pos.Offset += size
+	if r == '\n' {
+		s.pos.Line++
+		s.pos.Column = 1
+	} else {
+		s.pos.Column++
+	}
 	return r
 }
 
 func (s *Scanner) unread() {
 	_ = s.r.UnreadRune() // Ignore error as we know we will ever only read rune.
+	s.pos = s.prev
 }
 
 // scanWhitespace will consume any whitespace or tab rune until
@@ -103,50 +150,101 @@ func (s *Scanner) scanWhitespace() (Token, string) {
 	return WS, s.b.String()
 }
 
-// scanInteger will consume any integer rune until
-// a different rune is found and return 'INTEGER' token and its value.
-func (s *Scanner) scanInteger() (Token, string) {
+// scanNumber will consume a number of the form:
+// '[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?'
+// returning 'INTEGER' for whole numbers and 'FLOAT' for any number with a
+// period or exponent. When non finite values are enabled a sign may also
+// be followed by 'inf' or 'nan'. Anything else is returned as 'ILLEGAL'
+// with the runes consumed so far.
+func (s *Scanner) scanNumber() (Token, string) {
 	s.clearBuffer()
-	s.b.WriteRune(s.read())
+	tok := INTEGER
 
-	for {
-		r := s.read()
-		if isInteger(r) {
+	r := s.read()
+	if isSign(r) {
+		s.b.WriteRune(r)
+		r = s.read()
+	}
+
+	if s.nonFinite && isLetter(r) {
+		s.unread()
+		return s.scanNonFinite()
+	}
+
+	var digits int
+	if isInteger(r) {
+		s.b.WriteRune(r)
+		digits = 1 + s.scanDigits()
+		r = s.read()
+	}
+
+	if isPeriod(r) {
+		tok = FLOAT
+		s.b.WriteRune(r)
+		digits += s.scanDigits()
+		r = s.read()
+	}
+
+	// A sign or period on its own is not a number.
+	if digits == 0 {
+		s.unreadUnlessEOF(r)
+		return ILLEGAL, s.b.String()
+	}
+
+	if isExponent(r) {
+		tok = FLOAT
+		s.b.WriteRune(r)
+		r = s.read()
+		if isSign(r) {
 			s.b.WriteRune(r)
-		} else {
-			if r != rune(EOF) {
-				s.unread()
-			}
-			break
+			r = s.read()
+		}
+		if !isInteger(r) {
+			s.unreadUnlessEOF(r)
+			return ILLEGAL, s.b.String()
 		}
+		s.b.WriteRune(r)
+		s.scanDigits()
+		r = s.read()
 	}
+	s.unreadUnlessEOF(r)
 
-	return INTEGER, s.b.String()
+	return tok, s.b.String()
 }
 
-// scanNumber will consume any interger rune until a different rune other than
-// 'PERIOD' is found. If 'PERIOD' is found we know we have encounterd a floating point
-// number and we will continue to parse integers after the found 'PERIOD'.
-func (s *Scanner) scanNumber() (Token, string) {
-	var (
-		tok                   Token
-		val                   string
-		firstHalf, secondHalf string
-	)
-	tok, firstHalf = s.scanInteger()
-	r := s.read()
-	if isPeriod(r) {
-		tok, secondHalf = s.scanInteger()
-	} else {
-		s.unread()
+// scanDigits will consume any integer rune into the buffer until
+// a different rune is found and return how many were consumed.
+func (s *Scanner) scanDigits() int {
+	var n int
+	for {
+		r := s.read()
+		if !isInteger(r) {
+			s.unreadUnlessEOF(r)
+			return n
+		}
+		s.b.WriteRune(r)
+		n++
 	}
+}
 
-	val = firstHalf
-	if secondHalf != "" {
-		val += "." + secondHalf
+// scanNonFinite will consume letters following an optional sign already in the
+// buffer and return 'FLOAT' if they spell 'inf', 'infinity' or 'nan'.
+func (s *Scanner) scanNonFinite() (Token, string) {
+	var word bytes.Buffer
+	for {
+		r := s.read()
+		if !isLetter(r) {
+			s.unreadUnlessEOF(r)
+			break
+		}
+		word.WriteRune(r)
 	}
+	s.b.Write(word.Bytes())
 
-	return tok, val
+	if isNonFinite(word.String()) {
+		return FLOAT, s.b.String()
+	}
+	return ILLEGAL, s.b.String()
 }
 
 // scanString will consume any character rune until
@@ -192,19 +290,32 @@ func (s *Scanner) scanString() (Token, string) {
 		tok = ENDFACET
 	case "endsolid":
 		tok = ENDSOLID
+	default:
+		if s.nonFinite && isNonFinite(val) {
+			tok = FLOAT
+		}
 	}
 
 	return tok, val
 }
 
+// unreadUnlessEOF will put back the given rune unless reading failed.
+func (s *Scanner) unreadUnlessEOF(r rune) {
+	if r != rune(EOF) {
+		s.unread()
+	}
+}
+
 func (s *Scanner) clearBuffer() {
 	if s.b.Len() != 0 {
 		s.b.Reset()
 	}
 }
 
+// isWhitespace will report whether the rune is a space, a tab or a carriage
+// return, so CRLF line endings scan as whitespace followed by 'NEWLINE'.
 func isWhitespace(r rune) bool {
-	return r == ' ' || r == '\t'
+	return r == ' ' || r == '\t' || r == '\r'
 }
 
 func isLetter(r rune) bool {
@@ -218,3 +329,19 @@ func isInteger(r rune) bool {
 func isPeriod(r rune) bool {
 	return r == '.'
 }
+
+func isSign(r rune) bool {
+	return r == '+' || r == '-'
+}
+
+func isExponent(r rune) bool {
+	return r == 'e' || r == 'E'
+}
+
+func isNonFinite(val string) bool {
+	switch strings.ToLower(val) {
+	case "inf", "infinity", "nan":
+		return true
+	}
+	return false
+}
@@ -204,10 +204,73 @@ func TestScanNumber(t *testing.T) {
 		"floating point": {
 			input: strings.NewReader(`0.1234`),
 			expected: tokenPair{
-				tok: INTEGER,
+				tok: FLOAT,
 				val: "0.1234",
 			},
 		},
+		"negative number": {
+			input: strings.NewReader(`-1.5 `),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "-1.5",
+			},
+		},
+		"positive whole number": {
+			input: strings.NewReader(`+2`),
+			expected: tokenPair{
+				tok: INTEGER,
+				val: "+2",
+			},
+		},
+		"leading period": {
+			input: strings.NewReader(`.5`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: ".5",
+			},
+		},
+		"trailing period": {
+			input: strings.NewReader(`5.`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "5.",
+			},
+		},
+		"scientific notation": {
+			input: strings.NewReader(`1.234567e+02`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "1.234567e+02",
+			},
+		},
+		"uppercase exponent without fraction": {
+			input: strings.NewReader(`-3E-7`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "-3E-7",
+			},
+		},
+		"lone sign": {
+			input: strings.NewReader(`- `),
+			expected: tokenPair{
+				tok: ILLEGAL,
+				val: "-",
+			},
+		},
+		"missing exponent digits": {
+			input: strings.NewReader(`1e+`),
+			expected: tokenPair{
+				tok: ILLEGAL,
+				val: "1e+",
+			},
+		},
+		"non finite disabled": {
+			input: strings.NewReader(`-inf`),
+			expected: tokenPair{
+				tok: ILLEGAL,
+				val: "-",
+			},
+		},
 	}
 
 	// Act
@@ -257,3 +320,112 @@ func TestScanString(t *testing.T) {
 		})
 	}
 }
+
+func TestScanNonFinite(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input    io.Reader
+		expected tokenPair
+	}{
+		"infinity": {
+			input: strings.NewReader(`inf`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "inf",
+			},
+		},
+		"negative infinity": {
+			input: strings.NewReader(`-Infinity`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "-Infinity",
+			},
+		},
+		"not a number": {
+			input: strings.NewReader(`NaN`),
+			expected: tokenPair{
+				tok: FLOAT,
+				val: "NaN",
+			},
+		},
+		"signed word": {
+			input: strings.NewReader(`-foo`),
+			expected: tokenPair{
+				tok: ILLEGAL,
+				val: "-foo",
+			},
+		},
+		"keyword": {
+			input: strings.NewReader(`vertex`),
+			expected: tokenPair{
+				tok: VERTEX,
+				val: "vertex",
+			},
+		},
+	}
+
+	// Act
+	for testName, testCase := range tcs {
+		s := NewScanner(testCase.input, WithNonFinite())
+		t.Run(testName, func(t *testing.T) {
+			outTok, outVal := s.Scan()
+
+			// Assert
+			require.Equal(t, testCase.expected.tok, outTok)
+			require.Equal(t, testCase.expected.val, outVal)
+		})
+	}
+}
+
+func TestScanPosition(t *testing.T) {
+	// Arrange
+	var (
+		s        = NewScanner(strings.NewReader("solid é\n\tvertex -1.5\n"))
+		expected = []struct {
+			tok Token
+			pos Position
+		}{
+			{SOLID, Position{Line: 1, Column: 1, Offset: 0}},
+			{WS, Position{Line: 1, Column: 6, Offset: 5}},
+			{ILLEGAL, Position{Line: 1, Column: 7, Offset: 6}},
+			{NEWLINE, Position{Line: 1, Column: 8, Offset: 8}},
+			{WS, Position{Line: 2, Column: 1, Offset: 9}},
+			{VERTEX, Position{Line: 2, Column: 2, Offset: 10}},
+			{WS, Position{Line: 2, Column: 8, Offset: 16}},
+			{FLOAT, Position{Line: 2, Column: 9, Offset: 17}},
+			{NEWLINE, Position{Line: 2, Column: 13, Offset: 21}},
+			{EOF, Position{Line: 3, Column: 1, Offset: 22}},
+		}
+	)
+
+	// Act
+	for _, e := range expected {
+		tok, _ := s.Scan()
+
+		// Assert
+		require.Equal(t, e.tok, tok)
+		require.Equal(t, e.pos, s.Pos())
+	}
+}
+
+func TestScanCRLF(t *testing.T) {
+	// Arrange
+	var (
+		s        = NewScanner(strings.NewReader("endloop\r\nendfacet"))
+		expected = []tokenPair{
+			{ENDLOOP, "endloop"},
+			{WS, "\r"},
+			{NEWLINE, "\n"},
+			{ENDFACET, "endfacet"},
+		}
+	)
+
+	// Act
+	for _, tp := range expected {
+		tok, val := s.Scan()
+
+		// Assert
+		require.Equal(t, tp.tok, tok)
+		require.Equal(t, tp.val, val)
+	}
+}
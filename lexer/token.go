@@ -24,4 +24,5 @@ const (
 	VERTEX
 	WORD
 	INTEGER
+	FLOAT
 )
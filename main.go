@@ -1,39 +1,364 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/lenguti/STLParser/parser"
 )
 
+var (
+	listDuplicates = flag.Bool("duplicates", false, "list duplicate triangles instead of failing, loads the whole file in memory")
+	epsilon        = flag.Float64("epsilon", 0, "distance under which vertices are considered equal when listing duplicates")
+	rotations      = flag.Bool("rotations", false, "consider rotated vertices of a triangle as duplicates when listing duplicates")
+	winding        = flag.Bool("winding", false, "consider reversed winding of a triangle as duplicates when listing duplicates")
+	splitDir       = flag.String("split", "", "directory to write each connected component of the solids to as its own file")
+	layerHeight    = flag.Float64("slice", 0, "layer height to slice the solids at, written to the path given by -svg")
+	svgPath        = flag.String("svg", "", "directory to write one SVG file per layer to, or a '.svg' file to write every layer to")
+)
+
+// summary represents the statistics of a single solid.
+type summary struct {
+	name       string
+	stats      *parser.Stats
+	duplicates [][]int // Duplicate groups, only set when listing duplicates.
+}
+
 func main() {
-	if len(os.Args) == 1 {
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		runMerge(os.Args[2:])
+		return
+	}
+
+	flag.Parse()
+	if flag.NArg() == 0 {
 		log.Fatalf("main: unable to parse file argument [%v]", os.Args)
 	}
 
-	fileArg := os.Args[1]
+	fileArg := flag.Arg(0)
 	f, err := os.OpenFile(fileArg, os.O_RDONLY, 0755)
 	defer f.Close()
 	if err != nil {
 		log.Fatalf("main: unable to open file [%s]", err)
 	}
 
-	p := parser.New(f)
-	s, err := p.Parse()
+	d, format, err := parser.Open(f)
+	if err != nil {
+		log.Fatalf("main: unable to read file [%s]", err)
+	}
+
+	if *splitDir != "" {
+		if err := writeComponents(d, format, fileArg, *splitDir); err != nil {
+			log.Fatalf("main: unable to split file [%s]", err)
+		}
+		return
+	}
+
+	if *layerHeight > 0 {
+		if *svgPath == "" {
+			log.Fatalf("main: slicing requires an svg output path")
+		}
+		if err := writeLayers(d, fileArg, *layerHeight, *svgPath); err != nil {
+			log.Fatalf("main: unable to slice file [%s]", err)
+		}
+		return
+	}
+
+	var summaries []summary
+	if *listDuplicates {
+		summaries, err = summarizeModel(d, parser.DuplicateOptions{
+			Epsilon:        *epsilon,
+			IgnoreRotation: *rotations,
+			IgnoreWinding:  *winding,
+		})
+	} else {
+		summaries, err = summarizeStream(d)
+	}
 	if err != nil {
 		log.Fatalf("main: unable to parse file [%s]", err)
 	}
 
-	if s.CheckDuplicates() {
+	total := parser.NewStats()
+	for _, s := range summaries {
+		total.Merge(s.stats)
+	}
+
+	if !*listDuplicates && total.Duplicates > 0 {
 		log.Fatalf("main: stl file has duplicate triangles")
 	}
 
-	min, max := s.BoundingBox()
-	fmt.Printf("Number of triangles: %d\n", len(s.Facets))
-	fmt.Printf("Surface area       : %f\n", s.SurfaceArea())
-	fmt.Printf("Bounding box       : %+v %+v\n", min, max)
+	fmt.Printf("Format             : %s\n", format)
+	if len(summaries) > 1 {
+		for _, s := range summaries {
+			fmt.Printf("Solid              : %s\n", s.name)
+			printStats(s.stats)
+		}
+		fmt.Printf("Total solids       : %d\n", len(summaries))
+	}
+	printStats(total)
+
+	if *listDuplicates {
+		for _, s := range summaries {
+			for _, g := range s.duplicates {
+				fmt.Printf("Duplicate triangles: solid [%s] facets %v\n", s.name, g)
+			}
+		}
+	}
+}
+
+// summarizeStream will stream facets into the statistics of each solid so the
+// mesh is never held in memory.
+func summarizeStream(d parser.Decoder) ([]summary, error) {
+	var summaries []summary
+	for {
+		name, err := d.Name()
+		if err != nil {
+			return nil, err
+		}
+
+		stats := parser.NewStats()
+		for {
+			f, err := d.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			stats.Add(f)
+		}
+		summaries = append(summaries, summary{name: name, stats: stats})
+
+		more, err := d.More()
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			return summaries, nil
+		}
+	}
+}
+
+// summarizeModel will parse every solid in memory to find its duplicate triangles.
+func summarizeModel(d parser.Decoder, opts parser.DuplicateOptions) ([]summary, error) {
+	m, err := d.ParseAll()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]summary, len(m.Solids))
+	for i, s := range m.Solids {
+		stats := parser.NewStats()
+		for _, f := range s.Facets {
+			stats.Add(f)
+		}
+		summaries[i] = summary{name: s.Name, stats: stats, duplicates: s.FindDuplicates(opts)}
+	}
+	return summaries, nil
+}
+
+// writeComponents will split every solid into its connected components and write each
+// to its own file in dir, in the format of the input and named after the input file.
+func writeComponents(d parser.Decoder, format parser.Format, fileArg, dir string) error {
+	m, err := d.ParseAll()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var (
+		base = strings.TrimSuffix(filepath.Base(fileArg), filepath.Ext(fileArg))
+		n    int
+	)
+	for _, s := range m.Solids {
+		for _, part := range s.Split() {
+			n++
+			path := filepath.Join(dir, fmt.Sprintf("%s_%d.stl", base, n))
+			out, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			err = parser.NewEncoder(out, format).Encode(part)
+			if cerr := out.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Component          : %s [%s] %d triangles\n", path, part.Name, len(part.Facets))
+		}
+	}
+	return nil
+}
+
+// writeLayers will orient and slice every solid at the layer height and write the layers as SVG,
+// to a single document when path ends in '.svg' or else one document per layer in the path directory.
+func writeLayers(d parser.Decoder, fileArg string, height float64, path string) error {
+	m, err := d.ParseAll()
+	if err != nil {
+		return err
+	}
+	// Every layer is drawn over the bounding box of the solids so layers line up.
+	s, _ := parser.Merge(parser.MergeOptions{}, m.Solids...).Orient()
+	var (
+		lo, hi = s.BoundingBox()
+		layers = s.Layers(height)
+	)
+
+	if filepath.Ext(path) == ".svg" {
+		if err := writeSVG(path, lo, hi, layers...); err != nil {
+			return err
+		}
+		fmt.Printf("Layers             : %s %d layers\n", path, len(layers))
+		return nil
+	}
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	base := strings.TrimSuffix(filepath.Base(fileArg), filepath.Ext(fileArg))
+	for i, l := range layers {
+		p := filepath.Join(path, fmt.Sprintf("%s_layer_%04d.svg", base, i+1))
+		if err := writeSVG(p, lo, hi, l); err != nil {
+			return err
+		}
+		fmt.Printf("Layer              : %s z=%f %d contours\n", p, l.Z, len(l.Contours))
+	}
+	return nil
+}
+
+// writeSVG will write the layers to a new SVG file at path, each drawn over the bounds.
+func writeSVG(path string, lo, hi parser.Vector, layers ...parser.Layer) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	e := parser.NewSVGEncoder(out, parser.DefaultPrecision)
+	e.SetBounds(lo, hi)
+	err = e.Encode(layers...)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// runMerge will combine the solids of every file given in args into a single solid written
+// to the output file, as in 'merge -o plate.stl -translate 0,0,0 -translate 50,0,0 a.stl b.stl'.
+func runMerge(args []string) {
+	var (
+		fs      = flag.NewFlagSet("merge", flag.ExitOnError)
+		output  = fs.String("o", "", "file to write the merged solid to")
+		name    = fs.String("name", "", "name of the merged solid, defaults to the names of the solids joined with '+'")
+		weld    = fs.Float64("weld", 0, "distance under which vertices of a file are welded to vertices of the files before it")
+		binary  = fs.Bool("binary", false, "write a binary STL file instead of an ASCII one")
+		offsets offsetList
+	)
+	fs.Var(&offsets, "translate", "offset 'x,y,z' to move the solids of a file by, repeated for each file in order")
+	_ = fs.Parse(args)
+	if *output == "" || fs.NArg() == 0 {
+		log.Fatalf("main: merge expects an output file and input files [%s]", strings.Join(args, " "))
+	}
+
+	if len(offsets) > fs.NArg() {
+		log.Fatalf("main: merge expects at most one offset per file [%d offsets, %d files]", len(offsets), fs.NArg())
+	}
+
+	var (
+		solids     []parser.Solid
+		transforms []parser.Transform
+	)
+	for i, arg := range fs.Args() {
+		m, err := parseFile(arg)
+		if err != nil {
+			log.Fatalf("main: unable to parse file [%s] [%s]", arg, err)
+		}
+
+		t := parser.Identity()
+		if i < len(offsets) {
+			t = parser.Translate(offsets[i])
+		}
+		for range m.Solids {
+			transforms = append(transforms, t)
+		}
+		solids = append(solids, m.Solids...)
+	}
+	merged := parser.Merge(parser.MergeOptions{Name: *name, Transforms: transforms, Tolerance: *weld}, solids...)
+
+	format := parser.ASCII
+	if *binary {
+		format = parser.Binary
+	}
+	out, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("main: unable to create file [%s]", err)
+	}
+	err = parser.NewEncoder(out, format).Encode(merged)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		log.Fatalf("main: unable to write file [%s]", err)
+	}
+	fmt.Printf("Merged             : %s [%s] %d triangles\n", *output, merged.Name, len(merged.Facets))
+}
+
+// offsetList represents the offsets given by a repeated flag, each of the form 'x,y,z'.
+type offsetList []parser.Vector
+
+func (l *offsetList) String() string {
+	return fmt.Sprint(*l)
+}
+
+// Set will parse and append the offset 'x,y,z'.
+func (l *offsetList) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("offset [%s] must be of the form 'x,y,z'", value)
+	}
+
+	var coords [3]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fmt.Errorf("offset [%s] has an invalid coordinate [%s]", value, part)
+		}
+		coords[i] = f
+	}
+	*l = append(*l, parser.Vector{X: coords[0], Y: coords[1], Z: coords[2]})
+	return nil
+}
+
+// parseFile will open and parse every solid of the file at path.
+func parseFile(path string) (parser.Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return parser.Model{}, err
+	}
+	defer f.Close()
+
+	d, _, err := parser.Open(f)
+	if err != nil {
+		return parser.Model{}, err
+	}
+	return d.ParseAll()
+}
+
+// printStats will print the summary of the given statistics.
+func printStats(stats *parser.Stats) {
+	fmt.Printf("Number of triangles: %d\n", stats.Triangles)
+	fmt.Printf("Surface area       : %f\n", stats.SurfaceArea)
+	fmt.Printf("Volume             : %f\n", stats.Volume)
+	fmt.Printf("Closed mesh        : %t\n", stats.Closed())
+	fmt.Printf("Bounding box       : %+v %+v\n", stats.Min, stats.Max)
 }
 
 /*
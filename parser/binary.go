@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	binaryHeaderSize = 80 // Size of the free form header.
+	binaryCountSize  = 4  // Size of the little endian uint32 triangle count.
+	binaryFacetSize  = 50 // Size of a single facet record, 12 float32 values and a uint16 attribute.
+
+	// maxPreallocFacets caps how many facets we reserve up front so a corrupt
+	// triangle count cannot make us allocate gigabytes before reading any data.
+	maxPreallocFacets = 1 << 20
+)
+
+// BinaryParser represents our parsing object for reading contents of a binary STL file.
+// A binary STL file is laid out as an 80 byte header, a uint32 triangle count and one
+// 50 byte little endian record per facet.
+type BinaryParser struct {
+	r      io.Reader
+	record []byte // Reused buffer for a single facet record.
+
+	header    []byte // Raw header, read by 'Name'.
+	count     uint32 // Declared triangle count.
+	read      uint32 // Number of facets read so far.
+	started   bool   // Whether the header and triangle count have been read.
+	headerErr error  // Error found while reading the header and triangle count.
+	err       error  // First error returned by 'Next', including 'io.EOF'.
+}
+
+// NewBinary returns a pointer to a 'BinaryParser' reading from a buffered reader.
+func NewBinary(r io.Reader) *BinaryParser {
+	return &BinaryParser{
+		r:      bufio.NewReader(r),
+		record: make([]byte, binaryFacetSize),
+	}
+}
+
+// Parse will read the header, triangle count and every facet record
+// to build a 'Solid'. The raw header bytes are kept on the solid and the
+// header text up to the first NUL byte is used as the name of the solid.
+func (p *BinaryParser) Parse() (Solid, error) {
+	var s Solid
+	name, err := p.Name()
+	if err != nil {
+		return s, err
+	}
+	s.Name = name
+	s.Header = p.header
+
+	prealloc := p.count
+	if prealloc > maxPreallocFacets {
+		prealloc = maxPreallocFacets
+	}
+	s.Facets = make([]Facet, 0, prealloc)
+
+	for {
+		f, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return s, err
+		}
+		s.Facets = append(s.Facets, f)
+	}
+
+	return s, nil
+}
+
+// ParseAll will parse the file into a 'Model' holding its single solid,
+// as a binary STL file cannot hold more than one.
+func (p *BinaryParser) ParseAll() (Model, error) {
+	s, err := p.Parse()
+	if err != nil {
+		return Model{}, err
+	}
+	return Model{Solids: []Solid{s}}, nil
+}
+
+// More will always report false as a binary STL file holds a single solid.
+func (p *BinaryParser) More() (bool, error) {
+	return false, nil
+}
+
+// Name will read the header and triangle count the first time it is called
+// and return the name of the solid stored in the header.
+func (p *BinaryParser) Name() (string, error) {
+	if !p.started {
+		p.started = true
+		p.headerErr = p.parseHeader()
+	}
+	return headerName(p.header), p.headerErr
+}
+
+// parseHeader will read the 80 byte header followed by the triangle count.
+func (p *BinaryParser) parseHeader() error {
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(p.r, header); err != nil {
+		return errors.WithMessage(truncated(err), "parse binary: unable to read header")
+	}
+	p.header = header
+
+	countBuf := make([]byte, binaryCountSize)
+	if _, err := io.ReadFull(p.r, countBuf); err != nil {
+		return errors.WithMessage(truncated(err), "parse binary: unable to read triangle count")
+	}
+	p.count = binary.LittleEndian.Uint32(countBuf)
+
+	return nil
+}
+
+// Next will read and return the next facet record. Next returns 'io.EOF'
+// once as many facets as the declared triangle count have been read.
+// Once an error is returned every following call returns the same error.
+func (p *BinaryParser) Next() (Facet, error) {
+	if _, err := p.Name(); err != nil {
+		return Facet{}, err
+	}
+	if p.err != nil {
+		return Facet{}, p.err
+	}
+
+	if p.read == p.count {
+		p.err = io.EOF
+		return Facet{}, p.err
+	}
+
+	if _, err := io.ReadFull(p.r, p.record); err != nil {
+		p.err = errors.WithMessagef(truncated(err), "parse binary: unable to read facet [%d] of [%d]", p.read+1, p.count)
+		return Facet{}, p.err
+	}
+	p.read++
+
+	return decodeFacet(p.record), nil
+}
+
+// decodeFacet will decode a single 50 byte facet record of the form:
+// normal (3 x float32), vertices (9 x float32), attribute (uint16).
+func decodeFacet(b []byte) Facet {
+	f := NewFacet()
+	f.Normal = decodeVector(b[0:12])
+	for i := 0; i < 3; i++ {
+		offset := 12 + i*12
+		f.Vertices[i] = decodeVector(b[offset : offset+12])
+	}
+	f.Attribute = binary.LittleEndian.Uint16(b[48:50])
+	return f
+}
+
+// decodeVector will decode three little endian float32 values into a 'Vector'.
+func decodeVector(b []byte) Vector {
+	return Vector{
+		X: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[0:4]))),
+		Y: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[4:8]))),
+		Z: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[8:12]))),
+	}
+}
+
+// headerName will return the printable portion of a binary header,
+// everything up to the first NUL byte with surrounding whitespace removed.
+func headerName(header []byte) string {
+	if i := bytes.IndexByte(header, 0); i >= 0 {
+		header = header[:i]
+	}
+	return string(bytes.TrimSpace(header))
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// binaryFixture will build the bytes of a binary STL file from a header and facets.
+func binaryFixture(header string, facets []Facet) []byte {
+	var (
+		buf = new(bytes.Buffer)
+		h   = make([]byte, binaryHeaderSize)
+	)
+	copy(h, header)
+	buf.Write(h)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(facets)))
+	for _, f := range facets {
+		vs := append([]Vector{f.Normal}, f.Vertices...)
+		for _, v := range vs {
+			_ = binary.Write(buf, binary.LittleEndian, math.Float32bits(float32(v.X)))
+			_ = binary.Write(buf, binary.LittleEndian, math.Float32bits(float32(v.Y)))
+			_ = binary.Write(buf, binary.LittleEndian, math.Float32bits(float32(v.Z)))
+		}
+		_ = binary.Write(buf, binary.LittleEndian, f.Attribute)
+	}
+	return buf.Bytes()
+}
+
+func TestBinaryParse(t *testing.T) {
+	// Arrange
+	var (
+		facets = []Facet{
+			{
+				Normal: Vector{X: 0, Y: 0, Z: 1},
+				Vertices: []Vector{
+					{X: 0, Y: 0, Z: 0},
+					{X: 1, Y: 0, Z: 0},
+					{X: 1, Y: 1, Z: 0},
+				},
+				Attribute: 7,
+			},
+			{
+				Normal: Vector{X: 0, Y: 0, Z: -1},
+				Vertices: []Vector{
+					{X: -1.5, Y: 2.25, Z: 3},
+					{X: 4, Y: 5, Z: 6},
+					{X: 7, Y: 8, Z: 9},
+				},
+			},
+		}
+		data = binaryFixture("solid exported by cad", facets)
+		p    = NewBinary(bytes.NewReader(data))
+	)
+
+	// Act
+	s, err := p.Parse()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "solid exported by cad", s.Name)
+	require.Equal(t, data[:binaryHeaderSize], s.Header)
+	require.Equal(t, facets, s.Facets)
+}
+
+func TestBinaryParseErrors(t *testing.T) {
+	// Arrange
+	facets := []Facet{
+		{
+			Vertices: []Vector{
+				{X: 0, Y: 0, Z: 0},
+				{X: 1, Y: 0, Z: 0},
+				{X: 1, Y: 1, Z: 0},
+			},
+		},
+	}
+	data := binaryFixture("foo", facets)
+	tcs := map[string]struct {
+		input []byte
+	}{
+		"short header": {
+			input: data[:40],
+		},
+		"missing count": {
+			input: data[:binaryHeaderSize+2],
+		},
+		"truncated facet": {
+			input: data[:len(data)-10],
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := NewBinary(bytes.NewReader(tc.input)).Parse()
+
+			// Assert
+			require.True(t, errors.Is(err, ErrTruncated), "unexpected error [%v]", err)
+		})
+	}
+}
+
+func TestBinaryNext(t *testing.T) {
+	// Arrange
+	var (
+		facets = []Facet{
+			{
+				Vertices: []Vector{
+					{X: 0, Y: 0, Z: 0},
+					{X: 1, Y: 0, Z: 0},
+					{X: 1, Y: 1, Z: 0},
+				},
+				Attribute: 1,
+			},
+			{
+				Vertices: []Vector{
+					{X: 0, Y: 0, Z: 0},
+					{X: 0, Y: 1, Z: 0},
+					{X: 1, Y: 1, Z: 0},
+				},
+				Attribute: 2,
+			},
+		}
+		p = NewBinary(bytes.NewReader(binaryFixture("foo", facets)))
+	)
+
+	// Act
+	name, err := p.Name()
+	require.NoError(t, err)
+
+	var out []Facet
+	for {
+		f, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		out = append(out, f)
+	}
+
+	// Assert
+	require.Equal(t, "foo", name)
+	require.Equal(t, facets, out)
+}
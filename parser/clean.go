@@ -0,0 +1,63 @@
+package parser
+
+import "sort"
+
+// CleanOptions represents which facets are removed by 'Solid.Clean'.
+type CleanOptions struct {
+	Duplicates DuplicateOptions // How facets are compared when removing duplicates.
+	MinArea    float64          // Facets with an area at or under this are removed as degenerate.
+}
+
+// CleanReport represents the facets removed by 'Solid.Clean' as indices into the facets
+// of the original solid, in ascending order.
+type CleanReport struct {
+	Duplicates []int // Facets equal to an earlier facet that was kept.
+	Degenerate []int // Facets with collinear or coincident vertices, or without three vertices.
+}
+
+// Removed returns the number of facets removed.
+func (r CleanReport) Removed() int {
+	return len(r.Duplicates) + len(r.Degenerate)
+}
+
+// Clean will return a copy of the solid without its degenerate and duplicate facets, along
+// with a report of what was removed. Degenerate facets are the ones whose 'Facet.Area' is not
+// above the minimum area. Of every group found by 'Solid.FindDuplicates' only the first facet
+// that is not degenerate is kept.
+func (s Solid) Clean(opts CleanOptions) (Solid, CleanReport) {
+	var (
+		report  CleanReport
+		removed = make([]bool, len(s.Facets))
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 || f.Area() <= opts.MinArea {
+			removed[i] = true
+			report.Degenerate = append(report.Degenerate, i)
+		}
+	}
+
+	for _, g := range s.FindDuplicates(opts.Duplicates) {
+		kept := false
+		for _, i := range g {
+			if removed[i] {
+				continue
+			}
+			if !kept {
+				kept = true
+				continue
+			}
+			removed[i] = true
+			report.Duplicates = append(report.Duplicates, i)
+		}
+	}
+	sort.Ints(report.Duplicates)
+
+	out := Solid{Name: s.Name, Header: s.Header}
+	for i := 0; i < len(s.Facets); i++ {
+		if !removed[i] {
+			out.Facets = append(out.Facets, s.Facets[i])
+		}
+	}
+	return out, report
+}
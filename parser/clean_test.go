@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClean(t *testing.T) {
+	// Arrange
+	var (
+		a = Vector{X: 0, Y: 0, Z: 0}
+		b = Vector{X: 1, Y: 0, Z: 0}
+		c = Vector{X: 0, Y: 1, Z: 0}
+		d = Vector{X: 2, Y: 0, Z: 0}
+		s = Solid{
+			Name: "foo",
+			Facets: []Facet{
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{a, b, d}},
+				{Vertices: []Vector{b, c, a}},
+				{Vertices: []Vector{a, a, c}},
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{a, b}},
+				{Vertices: []Vector{{X: 1e-9, Y: 0, Z: 0}, b, c}},
+			},
+		}
+	)
+	tcs := map[string]struct {
+		opts     CleanOptions
+		facets   []int
+		expected CleanReport
+	}{
+		"exact": {
+			opts:     CleanOptions{},
+			facets:   []int{0, 2, 6},
+			expected: CleanReport{Duplicates: []int{4}, Degenerate: []int{1, 3, 5}},
+		},
+		"near duplicates": {
+			opts: CleanOptions{
+				Duplicates: DuplicateOptions{Epsilon: 1e-6, IgnoreRotation: true},
+			},
+			facets:   []int{0},
+			expected: CleanReport{Duplicates: []int{2, 4, 6}, Degenerate: []int{1, 3, 5}},
+		},
+		"minimum area": {
+			opts:     CleanOptions{MinArea: 1},
+			facets:   nil,
+			expected: CleanReport{Degenerate: []int{0, 1, 2, 3, 4, 5, 6}},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out, report := s.Clean(tc.opts)
+
+			// Assert
+			var facets []Facet
+			for _, i := range tc.facets {
+				facets = append(facets, s.Facets[i])
+			}
+			require.Equal(t, "foo", out.Name)
+			require.Equal(t, facets, out.Facets)
+			require.Equal(t, tc.expected, report)
+			require.Equal(t, len(s.Facets)-len(tc.facets), report.Removed())
+		})
+	}
+}
+
+func TestCleanKeepsFirstNonDegenerate(t *testing.T) {
+	// Arrange
+	var (
+		a = Vector{X: 0, Y: 0, Z: 0}
+		b = Vector{X: 1, Y: 0, Z: 0}
+		s = Solid{
+			Facets: []Facet{
+				{Vertices: []Vector{a, b, b}},
+				{Vertices: []Vector{a, b, b}},
+			},
+		}
+	)
+
+	// Act
+	out, report := s.Clean(CleanOptions{})
+
+	// Assert
+	require.Empty(t, out.Facets)
+	require.Empty(t, report.Duplicates)
+	require.Equal(t, []int{0, 1}, report.Degenerate)
+}
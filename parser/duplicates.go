@@ -0,0 +1,90 @@
+package parser
+
+// DuplicateOptions represents how facets are compared when looking for duplicates.
+type DuplicateOptions struct {
+	Epsilon        float64 // Vertices within this distance of each other are considered equal.
+	IgnoreRotation bool    // Whether cyclic rotations of the vertices are considered equal, '1 2 3' and '2 3 1'.
+	IgnoreWinding  bool    // Whether reversed vertices are considered equal, '1 2 3' and '3 2 1'.
+}
+
+// FindDuplicates will return every group of facets considered equal under the given options
+// as the indices into 'Solid.Facets' of each facet in the group. Groups are ordered by their
+// first facet and only hold more than one facet. Vertices are welded the same way as 'NewMesh'.
+func (s Solid) FindDuplicates(opts DuplicateOptions) [][]int {
+	var (
+		w      = newWelder(opts.Epsilon)
+		vs     []Vector
+		groups [][]int
+		index  = map[[3]int]int{}
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			continue
+		}
+
+		var t [3]int
+		for j, v := range f.Vertices {
+			t[j] = w.index(v, &vs)
+		}
+
+		key := duplicateKey(t, opts)
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	var duplicates [][]int
+	for _, g := range groups {
+		if len(g) > 1 {
+			duplicates = append(duplicates, g)
+		}
+	}
+	return duplicates
+}
+
+// duplicateKey will return the vertex indices of a triangle in a canonical order,
+// so triangles considered equal under the options share the same key.
+func duplicateKey(t [3]int, opts DuplicateOptions) [3]int {
+	key := t
+	if opts.IgnoreRotation {
+		key = rotateToMin(key)
+	}
+
+	if opts.IgnoreWinding {
+		reversed := [3]int{t[2], t[1], t[0]}
+		if opts.IgnoreRotation {
+			reversed = rotateToMin(reversed)
+		}
+		if lessIndices(reversed, key) {
+			key = reversed
+		}
+	}
+	return key
+}
+
+// rotateToMin will return the cyclic rotation of the indices that sorts first, so every
+// rotation of a triangle gives the same result even when indices repeat.
+func rotateToMin(t [3]int) [3]int {
+	first := t
+	for _, r := range [][3]int{{t[1], t[2], t[0]}, {t[2], t[0], t[1]}} {
+		if lessIndices(r, first) {
+			first = r
+		}
+	}
+	return first
+}
+
+// lessIndices will report whether a sorts before b lexicographically.
+func lessIndices(a, b [3]int) bool {
+	for i := 0; i < 3; i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
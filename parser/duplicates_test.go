@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	// Arrange
+	var (
+		a = Vector{X: 0, Y: 0, Z: 0}
+		b = Vector{X: 1, Y: 0, Z: 0}
+		c = Vector{X: 0, Y: 1, Z: 0}
+		s = Solid{
+			Facets: []Facet{
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{b, c, a}},
+				{Vertices: []Vector{c, b, a}},
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{{X: 0.04, Y: 0, Z: 0}, b, c}},
+				{Vertices: []Vector{{X: 0.01, Y: 0, Z: 0}, b, c}},
+				{Vertices: []Vector{a, b, {X: 0, Y: 1, Z: 1e-9}}},
+			},
+		}
+	)
+	tcs := map[string]struct {
+		opts     DuplicateOptions
+		expected [][]int
+	}{
+		"exact": {
+			opts:     DuplicateOptions{},
+			expected: [][]int{{0, 3}},
+		},
+		"rotations": {
+			opts:     DuplicateOptions{IgnoreRotation: true},
+			expected: [][]int{{0, 1, 3}},
+		},
+		"reversed winding": {
+			opts:     DuplicateOptions{IgnoreWinding: true},
+			expected: [][]int{{0, 2, 3}},
+		},
+		"rotations and reversed winding": {
+			opts:     DuplicateOptions{IgnoreRotation: true, IgnoreWinding: true},
+			expected: [][]int{{0, 1, 2, 3}},
+		},
+		"epsilon": {
+			opts:     DuplicateOptions{Epsilon: 1e-6},
+			expected: [][]int{{0, 3, 6}},
+		},
+		"wide epsilon": {
+			opts:     DuplicateOptions{Epsilon: 0.05, IgnoreRotation: true, IgnoreWinding: true},
+			expected: [][]int{{0, 1, 2, 3, 4, 5, 6}},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := s.FindDuplicates(tc.opts)
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestFindDuplicatesCollapsed(t *testing.T) {
+	// Arrange
+	var (
+		a = Vector{X: 0, Y: 0, Z: 0}
+		b = Vector{X: 1, Y: 0, Z: 0}
+		s = Solid{
+			Facets: []Facet{
+				{Vertices: []Vector{a, b, a}},
+				{Vertices: []Vector{b, a, a}},
+				{Vertices: []Vector{a, a, b}},
+			},
+		}
+	)
+
+	// Act
+	out := s.FindDuplicates(DuplicateOptions{IgnoreRotation: true})
+
+	// Assert
+	require.Equal(t, [][]int{{0, 1, 2}}, out)
+}
+
+func TestFindDuplicatesNone(t *testing.T) {
+	// Arrange
+	s := unitCube()
+
+	// Act
+	out := s.FindDuplicates(DuplicateOptions{IgnoreRotation: true, IgnoreWinding: true})
+
+	// Assert
+	require.Empty(t, out)
+}
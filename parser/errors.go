@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Classes of failures the parsers can run into. Every error caused by the
+// contents of the input matches one of these with 'errors.Is'.
+var (
+	ErrUnexpectedToken = errors.New("unexpected token")
+	ErrUnexpectedEOF   = errors.New("unexpected end of file")
+	ErrInvalidNumber   = errors.New("invalid number")
+	ErrTooManyPoints   = errors.New("too many points in vector")
+	ErrTooFewPoints    = errors.New("too few points in vector")
+	ErrTooManyVertices = errors.New("too many vertices in facet")
+	ErrTooFewVertices  = errors.New("too few vertices in facet")
+	ErrNameMismatch    = errors.New("solid names do not match")
+	ErrTruncated       = errors.New("binary file is truncated")
+)
+
+// SyntaxError represents a token found where the grammar of an ASCII STL
+// file expected something else, along with the position of that token.
+type SyntaxError struct {
+	Line, Col int    // Line and column of the token, starting at 1.
+	Offset    int    // Byte offset of the token from the start of the input.
+	Expected  string // Description of what the grammar expected.
+	Found     string // Value of the token that was found instead.
+	Err       error  // Class of the failure, one of the 'Err' sentinels.
+}
+
+// Error returns the position and class of the failure followed by what was found and expected.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, col %d: %v: found [%s], expected %s", e.Line, e.Col, e.Err, e.Found, e.Expected)
+}
+
+// Unwrap returns the class of the failure so it can be matched with 'errors.Is'.
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// truncated will report a short read of a binary file as 'ErrTruncated'
+// while passing any other read error through.
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrTruncated
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// sniffSize is how many leading bytes we inspect to determine the format of a file.
+const sniffSize = 512
+
+// Format represents the flavor an STL file was written in.
+type Format int
+
+const (
+	ASCII Format = iota
+	Binary
+)
+
+// String returns the human readable name of the format.
+func (f Format) String() string {
+	switch f {
+	case ASCII:
+		return "ascii"
+	case Binary:
+		return "binary"
+	}
+	return "unknown"
+}
+
+// Decoder represents behavior shared by the ASCII and binary parsers.
+// Parse and ParseAll build solids in memory while Name, Next and More
+// allow facets to be streamed one at a time.
+type Decoder interface {
+	Parse() (Solid, error)
+	ParseAll() (Model, error)
+	Name() (string, error)
+	Next() (Facet, error)
+	More() (bool, error)
+}
+
+// Open will inspect the beginning of the stream to determine if it holds an
+// ASCII or binary STL file and return the matching decoder along with the detected format.
+// When r is also an 'io.Seeker' the remaining length is compared against the triangle
+// count declared in the binary header, as binary headers frequently begin with 'solid'.
+// The options only apply to the ASCII parser.
+func Open(r io.Reader, opts ...Option) (Decoder, Format, error) {
+	size := remainingSize(r)
+	br := bufio.NewReaderSize(r, sniffSize)
+	peek, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF {
+		return nil, ASCII, errors.WithMessage(err, "open: unable to read stream")
+	}
+
+	format := detectFormat(peek, size)
+	if format == Binary {
+		return NewBinary(br), format, nil
+	}
+	return New(br, opts...), format, nil
+}
+
+// detectFormat will determine the format from the leading bytes of a file
+// and the remaining size of the stream, -1 if unknown. A file starting with 'solid'
+// is only ASCII when its leading bytes are text holding 'facet' or 'endsolid', or
+// are too few to tell.
+func detectFormat(peek []byte, size int64) Format {
+	if len(peek) >= binaryHeaderSize+binaryCountSize {
+		count := binary.LittleEndian.Uint32(peek[binaryHeaderSize:])
+		if size >= 0 && size == binaryHeaderSize+binaryCountSize+int64(count)*binaryFacetSize {
+			return Binary
+		}
+	}
+
+	// Anything too small to hold a binary header is left for the ASCII parser to reject.
+	if !bytes.HasPrefix(bytes.TrimLeft(peek, " \t\r\n"), []byte("solid")) {
+		if len(peek) >= binaryHeaderSize+binaryCountSize {
+			return Binary
+		}
+		return ASCII
+	}
+
+	switch {
+	case !isText(peek):
+		return Binary
+	case bytes.Contains(peek, []byte("facet")) || bytes.Contains(peek, []byte("endsolid")):
+		return ASCII
+	case len(peek) >= sniffSize:
+		// A full peek of text without a single keyword is a binary header and facets that
+		// only happen to be printable, as an ASCII facet is far shorter than the peek.
+		return Binary
+	}
+	return ASCII
+}
+
+// remainingSize will return how many bytes are left to read from r
+// or -1 if r cannot seek.
+func remainingSize(r io.Reader) int64 {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return -1
+	}
+
+	cur, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return -1
+	}
+	if _, err := seeker.Seek(cur, io.SeekStart); err != nil {
+		return -1
+	}
+	return end - cur
+}
+
+// isText will report whether b contains no NUL or control bytes other than whitespace.
+func isText(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+			return false
+		}
+		if c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const asciiFixture = `solid foo
+	facet normal 0 0 0
+		outer loop
+			vertex 0 0 0
+			vertex 1 0 0
+			vertex 1 1 1
+		endloop
+	endfacet
+endsolid foo
+`
+
+var binaryFixtureFacets = []Facet{
+	{
+		Normal: Vector{X: 0, Y: 0, Z: 1},
+		Vertices: []Vector{
+			{X: 0, Y: 0, Z: 0},
+			{X: 1, Y: 0, Z: 0},
+			{X: 1, Y: 1, Z: 0},
+		},
+	},
+}
+
+// onlyReader hides any seeking behavior of the underlying reader.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func TestOpen(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input    io.Reader
+		expected Format
+	}{
+		"ascii file": {
+			input:    strings.NewReader(asciiFixture),
+			expected: ASCII,
+		},
+		"binary file": {
+			input:    bytes.NewReader(binaryFixture("exported", binaryFixtureFacets)),
+			expected: Binary,
+		},
+		"binary file with solid header": {
+			input:    bytes.NewReader(binaryFixture("solid exported facet endsolid", binaryFixtureFacets)),
+			expected: Binary,
+		},
+		"binary file with solid header without seeking": {
+			input:    onlyReader{r: bytes.NewReader(binaryFixture("solid exported", binaryFixtureFacets))},
+			expected: Binary,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			d, format, err := Open(tc.input)
+			require.NoError(t, err)
+			s, err := d.Parse()
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, format)
+			require.Len(t, s.Facets, 1)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		peek     []byte
+		size     int64
+		expected Format
+	}{
+		"ascii keywords": {
+			peek:     []byte(asciiFixture),
+			size:     -1,
+			expected: ASCII,
+		},
+		"short non solid input": {
+			peek:     []byte("foo"),
+			size:     3,
+			expected: ASCII,
+		},
+		"declared count matches size": {
+			peek:     binaryFixture("solid facet endsolid", binaryFixtureFacets),
+			size:     134,
+			expected: Binary,
+		},
+		"no solid prefix": {
+			peek:     binaryFixture("exported", binaryFixtureFacets),
+			size:     -1,
+			expected: Binary,
+		},
+		"printable peek without keywords": {
+			peek:     []byte("solid " + strings.Repeat("x", sniffSize-6)),
+			size:     -1,
+			expected: Binary,
+		},
+		"printable peek with keywords": {
+			peek:     []byte("solid " + strings.Repeat("x", sniffSize-11) + "facet"),
+			size:     -1,
+			expected: ASCII,
+		},
+		"short printable input without keywords": {
+			peek:     []byte("solid " + strings.Repeat("x", 100)),
+			size:     -1,
+			expected: ASCII,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := detectFormat(tc.peek, tc.size)
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package parser
+
+import "math"
+
+// FillReport represents the changes made by 'Solid.FillHoles'.
+type FillReport struct {
+	Holes   int     // Number of boundary loops closed.
+	Facets  int     // Number of facets appended.
+	Area    float64 // Total area of the appended facets.
+	Skipped int     // Number of boundary chains that do not close into a loop and were left open.
+}
+
+// FillHoles will return a copy of the solid with every loop of open edges closed by new facets
+// appended after the existing ones, along with a report of the changes.
+func (s Solid) FillHoles() (Solid, FillReport) {
+	var (
+		report FillReport
+		out    = Solid{Name: s.Name, Header: s.Header, Facets: append([]Facet{}, s.Facets...)}
+	)
+	// Loops are wound against the facets around them, so a consistent orientation is kept.
+	for _, loop := range boundaryLoops(s.Facets, &report.Skipped) {
+		report.Holes++
+		for _, t := range triangulate(loop) {
+			f := Facet{Vertices: []Vector{t[0], t[1], t[2]}}
+			f.Normal = f.GeometricNormal()
+			out.Facets = append(out.Facets, f)
+			report.Facets++
+			report.Area += f.Area()
+		}
+	}
+	return out, report
+}
+
+// boundaryLoops will trace the open edges of the facets into closed loops of vertices. Open
+// edges are walked in reverse, the winding a facet filling the hole needs. Chains that can
+// not be closed are counted into skipped.
+func boundaryLoops(facets []Facet, skipped *int) [][]Vector {
+	counts := map[edge]int{}
+	for i := 0; i < len(facets); i++ {
+		if len(facets[i].Vertices) != 3 {
+			continue
+		}
+		for _, e := range facetEdges(facets[i]) {
+			if e.a != e.b {
+				counts[e.undirected()]++
+			}
+		}
+	}
+
+	var (
+		open     []edge
+		outgoing = map[Vector][]int{}
+	)
+	for i := 0; i < len(facets); i++ {
+		if len(facets[i].Vertices) != 3 {
+			continue
+		}
+		for _, e := range facetEdges(facets[i]) {
+			if e.a == e.b || counts[e.undirected()] != 1 {
+				continue
+			}
+			outgoing[e.b] = append(outgoing[e.b], len(open))
+			open = append(open, e.reverse())
+		}
+	}
+
+	var (
+		loops [][]Vector
+		used  = make([]bool, len(open))
+	)
+	for i := range open {
+		if used[i] {
+			continue
+		}
+
+		var (
+			start = open[i].a
+			loop  = []Vector{start}
+			j     = i
+		)
+		for {
+			used[j] = true
+			next := open[j].b
+			if next == start {
+				break
+			}
+			loop = append(loop, next)
+
+			j = -1
+			for _, k := range outgoing[next] {
+				if !used[k] {
+					j = k
+					break
+				}
+			}
+			if j < 0 {
+				break
+			}
+		}
+
+		if j < 0 || len(loop) < 3 {
+			*skipped++
+			continue
+		}
+		loops = append(loops, loop)
+	}
+	return loops
+}
+
+// triangulate will split the polygon into triangles wound the same way by ear clipping.
+// The polygon is projected on the plane perpendicular to its Newell normal, where its
+// winding is counter clockwise.
+func triangulate(polygon []Vector) [][3]Vector {
+	var n Vector
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		n = n.add(Vector{
+			X: (a.Y - b.Y) * (a.Z + b.Z),
+			Y: (a.Z - b.Z) * (a.X + b.X),
+			Z: (a.X - b.X) * (a.Y + b.Y),
+		})
+	}
+
+	// Project on two axes u and v with u x v along the normal.
+	axis := Vector{X: 1}
+	if math.Abs(n.Y) <= math.Abs(n.X) && math.Abs(n.Y) <= math.Abs(n.Z) {
+		axis = Vector{Y: 1}
+	} else if math.Abs(n.Z) <= math.Abs(n.X) {
+		axis = Vector{Z: 1}
+	}
+	u := n.cross(axis)
+	v := n.cross(u)
+	points := make([][2]float64, len(polygon))
+	for i, p := range polygon {
+		points[i] = [2]float64{p.dot(u), p.dot(v)}
+	}
+
+	var (
+		triangles [][3]Vector
+		remaining = make([]int, len(polygon))
+	)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	for len(remaining) > 3 {
+		var (
+			ear  = -1
+			best = -1
+			turn = math.Inf(-1)
+		)
+		for i := range remaining {
+			var (
+				a = points[remaining[(i+len(remaining)-1)%len(remaining)]]
+				b = points[remaining[i]]
+				c = points[remaining[(i+1)%len(remaining)]]
+				k = cross2(a, b, c)
+			)
+			if k > turn {
+				best, turn = i, k
+			}
+			if k <= 0 {
+				continue
+			}
+
+			inside := false
+			for _, j := range remaining {
+				p := points[j]
+				if p == a || p == b || p == c {
+					continue
+				}
+				if cross2(a, b, p) >= 0 && cross2(b, c, p) >= 0 && cross2(c, a, p) >= 0 {
+					inside = true
+					break
+				}
+			}
+			if !inside {
+				ear = i
+				break
+			}
+		}
+
+		// A polygon without any ear folds over itself, clip its most convex corner instead.
+		if ear < 0 {
+			ear = best
+		}
+
+		var (
+			prev = remaining[(ear+len(remaining)-1)%len(remaining)]
+			next = remaining[(ear+1)%len(remaining)]
+		)
+		triangles = append(triangles, [3]Vector{polygon[prev], polygon[remaining[ear]], polygon[next]})
+		remaining = append(remaining[:ear], remaining[ear+1:]...)
+	}
+
+	return append(triangles, [3]Vector{polygon[remaining[0]], polygon[remaining[1]], polygon[remaining[2]]})
+}
+
+// cross2 returns the z component of the cross product of b-a and c-b, positive when
+// a, b and c turn counter clockwise.
+func cross2(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-b[1]) - (b[1]-a[1])*(c[0]-b[0])
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// without returns the solid without the facets at the given indices.
+func without(s Solid, indices ...int) Solid {
+	skip := map[int]bool{}
+	for _, i := range indices {
+		skip[i] = true
+	}
+	out := Solid{Name: s.Name}
+	for i, f := range s.Facets {
+		if !skip[i] {
+			out.Facets = append(out.Facets, f)
+		}
+	}
+	return out
+}
+
+func TestFillHoles(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		solid    Solid
+		expected FillReport
+		volume   float64
+	}{
+		"closed": {
+			solid:    unitCube(),
+			expected: FillReport{},
+			volume:   1,
+		},
+		"triangle": {
+			solid:    without(unitCube(), 2),
+			expected: FillReport{Holes: 1, Facets: 1, Area: 0.5},
+			volume:   1,
+		},
+		"square": {
+			solid:    without(unitCube(), 2, 3),
+			expected: FillReport{Holes: 1, Facets: 2, Area: 1},
+			volume:   1,
+		},
+		"two holes": {
+			solid:    without(unitCube(), 0, 1, 2, 3),
+			expected: FillReport{Holes: 2, Facets: 4, Area: 2},
+			volume:   1,
+		},
+		"non planar": {
+			solid:    without(unitCube(), 2, 3, 4, 5),
+			expected: FillReport{Holes: 1, Facets: 4},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out, report := tc.solid.FillHoles()
+
+			// Assert
+			require.Equal(t, tc.expected.Holes, report.Holes)
+			require.Equal(t, tc.expected.Facets, report.Facets)
+			require.Equal(t, tc.expected.Skipped, report.Skipped)
+			require.Len(t, out.Facets, len(tc.solid.Facets)+report.Facets)
+			require.True(t, out.AnalyzeEdges().Watertight())
+			require.Empty(t, out.CheckNormals(NormalOptions{}))
+
+			volume, ok := out.Volume()
+			require.True(t, ok)
+			if tc.volume > 0 {
+				require.InDelta(t, tc.expected.Area, report.Area, 1e-12)
+				require.InDelta(t, tc.volume, volume, 1e-12)
+			}
+		})
+	}
+}
+
+func TestFillHolesConcave(t *testing.T) {
+	// Arrange
+	var (
+		// An L shaped outline, whose ear at the reflex corner must not be clipped.
+		outline = []Vector{
+			{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2},
+		}
+		apex = Vector{X: 0.5, Y: 0.5, Z: 1}
+		s    Solid
+	)
+	for i := range outline {
+		s.Facets = append(s.Facets, Facet{Vertices: []Vector{outline[i], outline[(i+1)%len(outline)], apex}})
+	}
+
+	// Act
+	out, report := s.FillHoles()
+
+	// Assert
+	require.Equal(t, FillReport{Holes: 1, Facets: 4, Area: 3}, report)
+	require.True(t, out.AnalyzeEdges().Watertight())
+	for _, f := range out.Facets[len(s.Facets):] {
+		require.Equal(t, Vector{Z: -1}, f.Normal)
+	}
+}
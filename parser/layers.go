@@ -0,0 +1,31 @@
+package parser
+
+import "math"
+
+// Layer represents the cross section of a solid at a height along the Z axis.
+type Layer struct {
+	Z        float64
+	Contours []Contour
+}
+
+// Layers will slice the solid into layers of the given height from the bottom of its bounding
+// box, see 'Solid.Slice', returning at least one layer for a positive height and any facets.
+func (s Solid) Layers(height float64) []Layer {
+	if height <= 0 || len(s.Facets) == 0 {
+		return nil
+	}
+
+	var (
+		lo, hi = s.BoundingBox()
+		count  = int(math.Max(1, math.Ceil((hi.Z-lo.Z)/height)))
+		layers = make([]Layer, 0, count)
+	)
+	// Each plane sits in the middle of the part of its layer inside the bounding box, so none
+	// grazes a flat bottom or top. Layers without contours are kept so numbers match heights.
+	for i := 0; i < count; i++ {
+		bottom := lo.Z + height*float64(i)
+		z := min(bottom+height/2, (bottom+hi.Z)/2)
+		layers = append(layers, Layer{Z: z, Contours: s.Slice(HorizontalPlane(z))})
+	}
+	return layers
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayers(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		solid    Solid
+		height   float64
+		expected []float64
+	}{
+		"even": {
+			solid:    unitCube(),
+			height:   0.25,
+			expected: []float64{0.125, 0.375, 0.625, 0.875},
+		},
+		"uneven": {
+			solid:    unitCube().Translate(Vector{Z: 2}),
+			height:   0.4,
+			expected: []float64{2.2, 2.6, 2.9},
+		},
+		"thick": {
+			solid:    unitCube(),
+			height:   3,
+			expected: []float64{0.5},
+		},
+		"zero height": {
+			solid:    unitCube(),
+			height:   0,
+			expected: nil,
+		},
+		"empty": {
+			solid:    Solid{},
+			height:   1,
+			expected: nil,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := tc.solid.Layers(tc.height)
+
+			// Assert
+			require.Len(t, out, len(tc.expected))
+			for i, z := range tc.expected {
+				require.InDelta(t, z, out[i].Z, 1e-12)
+				require.Len(t, out[i].Contours, 1)
+				require.InDelta(t, 1, out[i].Contours[0].Area, 1e-12)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import "github.com/pkg/errors"
+
+// MassProperties represents the volumetric properties of a closed solid of uniform density.
+type MassProperties struct {
+	Volume   float64       // Enclosed volume.
+	Mass     float64       // Volume multiplied by the density.
+	Centroid Vector        // Center of mass.
+	Inertia  [3][3]float64 // Inertia tensor about the center of mass, scaled by the density.
+}
+
+// SurfaceCentroid will calculate and return the centroid of the surface of the solid,
+// the average of the facet centroids weighted by their area.
+func (s Solid) SurfaceCentroid() Vector {
+	var (
+		sum  Vector
+		area float64
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		a := f.Area()
+		if a == 0 {
+			continue
+		}
+		sum = sum.add(f.centroid().scale(a))
+		area += a
+	}
+
+	if area == 0 {
+		return Vector{}
+	}
+	return sum.scale(1 / area)
+}
+
+// MassProperties will calculate and return the volume, mass, center of mass and inertia
+// tensor of the solid for the given uniform density, which must be positive. The results
+// are only meaningful for a closed mesh with a consistent orientation, see 'Solid.Volume',
+// and a negative volume from facets wound inward is rejected, see 'Solid.Orient'.
+//
+// The volume integrals are accumulated per facet from the same cross product used by
+// 'Facet.Area', following D. Eberly, "Polyhedral Mass Properties (Revisited)".
+func (s Solid) MassProperties(density float64) (MassProperties, error) {
+	if !(density > 0) {
+		return MassProperties{}, errors.Errorf("mass properties: density [%v] must be positive", density)
+	}
+
+	// Integrals of 1, x, y, z, x^2, y^2, z^2, xy, yz and zx over the volume.
+	var intg [10]float64
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			continue
+		}
+
+		var (
+			p0, p1, p2 = f.Vertices[0], f.Vertices[1], f.Vertices[2]
+			d          = f.crossProduct()
+			x          = subexpressions(p0.X, p1.X, p2.X)
+			y          = subexpressions(p0.Y, p1.Y, p2.Y)
+			z          = subexpressions(p0.Z, p1.Z, p2.Z)
+		)
+		intg[0] += d.X * x.f1
+		intg[1] += d.X * x.f2
+		intg[2] += d.Y * y.f2
+		intg[3] += d.Z * z.f2
+		intg[4] += d.X * x.f3
+		intg[5] += d.Y * y.f3
+		intg[6] += d.Z * z.f3
+		intg[7] += d.X * (p0.Y*x.g0 + p1.Y*x.g1 + p2.Y*x.g2)
+		intg[8] += d.Y * (p0.Z*y.g0 + p1.Z*y.g1 + p2.Z*y.g2)
+		intg[9] += d.Z * (p0.X*z.g0 + p1.X*z.g1 + p2.X*z.g2)
+	}
+
+	intg[0] /= 6
+	for i := 1; i <= 3; i++ {
+		intg[i] /= 24
+	}
+	for i := 4; i <= 6; i++ {
+		intg[i] /= 60
+	}
+	for i := 7; i <= 9; i++ {
+		intg[i] /= 120
+	}
+
+	volume := intg[0]
+	if volume < 0 {
+		return MassProperties{}, errors.Errorf("mass properties: volume [%v] is negative, facets are wound inward", volume)
+	}
+
+	mp := MassProperties{Volume: volume, Mass: volume * density}
+	if volume == 0 {
+		return mp, nil
+	}
+
+	c := Vector{X: intg[1] / volume, Y: intg[2] / volume, Z: intg[3] / volume}
+	mp.Centroid = c
+
+	// Inertia relative to the center of mass.
+	var (
+		xx = intg[5] + intg[6] - volume*(c.Y*c.Y+c.Z*c.Z)
+		yy = intg[4] + intg[6] - volume*(c.Z*c.Z+c.X*c.X)
+		zz = intg[4] + intg[5] - volume*(c.X*c.X+c.Y*c.Y)
+		xy = -(intg[7] - volume*c.X*c.Y)
+		yz = -(intg[8] - volume*c.Y*c.Z)
+		xz = -(intg[9] - volume*c.Z*c.X)
+	)
+	mp.Inertia = [3][3]float64{
+		{xx * density, xy * density, xz * density},
+		{xy * density, yy * density, yz * density},
+		{xz * density, yz * density, zz * density},
+	}
+
+	return mp, nil
+}
+
+// centroid will calculate and return the average of the three vertices of the facet.
+func (f Facet) centroid() Vector {
+	if len(f.Vertices) != 3 {
+		return Vector{}
+	}
+	return f.Vertices[0].add(f.Vertices[1]).add(f.Vertices[2]).scale(1.0 / 3)
+}
+
+// polynomials represents the per axis subexpressions of the volume integrals of a facet.
+type polynomials struct {
+	f1, f2, f3 float64
+	g0, g1, g2 float64
+}
+
+// subexpressions will calculate the polynomials of one axis from the
+// coordinates w0, w1 and w2 of the three vertices of a facet.
+func subexpressions(w0, w1, w2 float64) polynomials {
+	var (
+		temp0 = w0 + w1
+		f1    = temp0 + w2
+		temp1 = w0 * w0
+		temp2 = temp1 + w1*temp0
+		f2    = temp2 + w2*f1
+		f3    = w0*temp1 + w1*temp2 + w2*f2
+	)
+	return polynomials{
+		f1: f1,
+		f2: f2,
+		f3: f3,
+		g0: f2 + w0*(f1+w0),
+		g1: f2 + w1*(f1+w1),
+		g2: f2 + w2*(f1+w2),
+	}
+}
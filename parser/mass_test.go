@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// unitTetrahedron returns a closed, outward wound tetrahedron with a corner at the origin.
+func unitTetrahedron() Solid {
+	var (
+		o = Vector{X: 0, Y: 0, Z: 0}
+		x = Vector{X: 1, Y: 0, Z: 0}
+		y = Vector{X: 0, Y: 1, Z: 0}
+		z = Vector{X: 0, Y: 0, Z: 1}
+	)
+	return Solid{
+		Name: "tetrahedron",
+		Facets: []Facet{
+			{Vertices: []Vector{o, y, x}},
+			{Vertices: []Vector{o, x, z}},
+			{Vertices: []Vector{o, z, y}},
+			{Vertices: []Vector{x, y, z}},
+		},
+	}
+}
+
+func TestMassProperties(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		solid   Solid
+		density float64
+		volume  float64
+		mass    float64
+		center  Vector
+		inertia [3][3]float64
+	}{
+		"cube with density": {
+			solid:   unitCube(),
+			density: 2,
+			volume:  1,
+			mass:    2,
+			center:  Vector{X: .5, Y: .5, Z: .5},
+			inertia: [3][3]float64{
+				{1.0 / 3, 0, 0},
+				{0, 1.0 / 3, 0},
+				{0, 0, 1.0 / 3},
+			},
+		},
+		"tetrahedron with unit density": {
+			solid:   unitTetrahedron(),
+			density: 1,
+			volume:  1.0 / 6,
+			mass:    1.0 / 6,
+			center:  Vector{X: .25, Y: .25, Z: .25},
+			inertia: [3][3]float64{
+				{1.0 / 80, 1.0 / 480, 1.0 / 480},
+				{1.0 / 480, 1.0 / 80, 1.0 / 480},
+				{1.0 / 480, 1.0 / 480, 1.0 / 80},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			mp, err := tc.solid.MassProperties(tc.density)
+
+			// Assert
+			require.NoError(t, err)
+			require.InDelta(t, tc.volume, mp.Volume, 1e-12)
+			require.InDelta(t, tc.mass, mp.Mass, 1e-12)
+			require.InDelta(t, tc.center.X, mp.Centroid.X, 1e-12)
+			require.InDelta(t, tc.center.Y, mp.Centroid.Y, 1e-12)
+			require.InDelta(t, tc.center.Z, mp.Centroid.Z, 1e-12)
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					require.InDelta(t, tc.inertia[i][j], mp.Inertia[i][j], 1e-12, "inertia [%d][%d]", i, j)
+				}
+			}
+		})
+	}
+}
+
+func TestMassPropertiesInvalidDensity(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		density float64
+	}{
+		"zero":     {density: 0},
+		"negative": {density: -1},
+		"nan":      {density: math.NaN()},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := unitCube().MassProperties(tc.density)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMassPropertiesInwardWound(t *testing.T) {
+	// Arrange
+	s := unitCube()
+	for i := range s.Facets {
+		s.Facets[i] = s.Facets[i].reversed()
+	}
+
+	// Act
+	_, err := s.MassProperties(1)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestSurfaceCentroid(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		solid    Solid
+		expected Vector
+	}{
+		"cube": {
+			solid:    unitCube(),
+			expected: Vector{X: .5, Y: .5, Z: .5},
+		},
+		"single facet": {
+			solid: Solid{
+				Facets: []Facet{
+					{Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 3, Y: 0, Z: 0}, {X: 0, Y: 3, Z: 0}}},
+				},
+			},
+			expected: Vector{X: 1, Y: 1, Z: 0},
+		},
+		"empty": {
+			solid:    Solid{},
+			expected: Vector{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := tc.solid.SurfaceCentroid()
+
+			// Assert
+			require.InDelta(t, tc.expected.X, out.X, 1e-12)
+			require.InDelta(t, tc.expected.Y, out.Y, 1e-12)
+			require.InDelta(t, tc.expected.Z, out.Z, 1e-12)
+		})
+	}
+}
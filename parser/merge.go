@@ -0,0 +1,58 @@
+package parser
+
+import "strings"
+
+// MergeOptions represents how solids are combined by 'Merge'.
+type MergeOptions struct {
+	Name       string      // Name of the merged solid, the names of the solids joined with '+' when empty.
+	Transforms []Transform // Transformation of the solid at the same position, solids past the end are left in place.
+	Tolerance  float64     // Vertices within this distance of a vertex of an earlier solid are welded into it, 0 leaves them untouched.
+}
+
+// Merge will combine the facets of the solids, in order, into a single solid, welding
+// vertices at the seams between solids when the tolerance is positive.
+func Merge(opts MergeOptions, solids ...Solid) Solid {
+	out := Solid{Name: opts.Name}
+	if out.Name == "" {
+		var names []string
+		for _, s := range solids {
+			if s.Name != "" {
+				names = append(names, s.Name)
+			}
+		}
+		out.Name = strings.Join(names, "+")
+	}
+
+	// Only vertices of later solids are moved onto vertices of earlier solids, so the
+	// features within a solid are never collapsed by welding.
+	var (
+		w      = newWelder(opts.Tolerance)
+		welded []Vector
+	)
+	for i, s := range solids {
+		if i < len(opts.Transforms) {
+			s = s.Transform(opts.Transforms[i])
+		}
+		if opts.Tolerance <= 0 {
+			out.Facets = append(out.Facets, s.Facets...)
+			continue
+		}
+
+		start := len(out.Facets)
+		for _, f := range s.Facets {
+			f.Vertices = append([]Vector(nil), f.Vertices...)
+			for j, v := range f.Vertices {
+				if k, ok := w.find(v, welded); ok {
+					f.Vertices[j] = welded[k]
+				}
+			}
+			out.Facets = append(out.Facets, f)
+		}
+		for _, f := range out.Facets[start:] {
+			for _, v := range f.Vertices {
+				w.index(v, &welded)
+			}
+		}
+	}
+	return out
+}
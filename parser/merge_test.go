@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	// Arrange
+	var (
+		a = Vector{X: 0, Y: 0, Z: 0}
+		b = Vector{X: 1, Y: 0, Z: 0}
+		c = Vector{X: 1, Y: 1, Z: 0}
+		d = Vector{X: 0, Y: 1, Z: 0}
+
+		left  = Solid{Name: "left", Header: []byte("left"), Facets: []Facet{{Vertices: []Vector{a, b, c}, Attribute: 1}}}
+		right = Solid{Name: "right", Facets: []Facet{{Vertices: []Vector{{X: 1e-7}, {X: 1, Y: 1, Z: 1e-7}, d}, Attribute: 2}}}
+	)
+	tcs := map[string]struct {
+		opts     MergeOptions
+		solids   []Solid
+		expected Solid
+	}{
+		"concatenate": {
+			opts:   MergeOptions{},
+			solids: []Solid{left, right},
+			expected: Solid{
+				Name:   "left+right",
+				Facets: []Facet{left.Facets[0], right.Facets[0]},
+			},
+		},
+		"named": {
+			opts:   MergeOptions{Name: "plate"},
+			solids: []Solid{left, {Facets: right.Facets}},
+			expected: Solid{
+				Name:   "plate",
+				Facets: []Facet{left.Facets[0], right.Facets[0]},
+			},
+		},
+		"unnamed": {
+			opts:   MergeOptions{},
+			solids: []Solid{{Facets: left.Facets}, right},
+			expected: Solid{
+				Name:   "right",
+				Facets: []Facet{left.Facets[0], right.Facets[0]},
+			},
+		},
+		"welded": {
+			opts:   MergeOptions{Tolerance: 1e-6},
+			solids: []Solid{left, right},
+			expected: Solid{
+				Name: "left+right",
+				Facets: []Facet{
+					{Vertices: []Vector{a, b, c}, Attribute: 1},
+					{Vertices: []Vector{a, c, d}, Attribute: 2},
+				},
+			},
+		},
+		"welded only at seams": {
+			opts:   MergeOptions{Tolerance: 1e-6},
+			solids: []Solid{{Facets: []Facet{{Vertices: []Vector{a, {X: 1e-7}, c}}}}},
+			expected: Solid{
+				Facets: []Facet{{Vertices: []Vector{a, {X: 1e-7}, c}}},
+			},
+		},
+		"transformed": {
+			opts:   MergeOptions{Transforms: []Transform{Identity(), Translate(Vector{Z: 1})}},
+			solids: []Solid{left, right, left},
+			expected: Solid{
+				Name:   "left+right+left",
+				Facets: []Facet{left.Facets[0], right.Translate(Vector{Z: 1}).Facets[0], left.Facets[0]},
+			},
+		},
+		"empty": {
+			opts:     MergeOptions{},
+			solids:   nil,
+			expected: Solid{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := Merge(tc.opts, tc.solids...)
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestMergeSplit(t *testing.T) {
+	// Arrange
+	var (
+		cube  = unitCube()
+		moved = unitCube().Transform(Rotate(Vector{Z: 1}, 1).Then(Translate(Vector{X: 5})))
+	)
+
+	// Act
+	out := Merge(MergeOptions{Name: "plate"}, cube, moved)
+
+	// Assert
+	require.Equal(t, []Solid{
+		{Name: "plate_1", Facets: cube.Facets},
+		{Name: "plate_2", Facets: moved.Facets},
+	}, out.Split())
+}
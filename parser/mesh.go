@@ -0,0 +1,136 @@
+package parser
+
+import "math"
+
+// Mesh represents a solid as a list of unique vertices shared by triangles,
+// each triangle holding the indices of its three vertices.
+type Mesh struct {
+	Name       string
+	Header     []byte
+	Vertices   []Vector
+	Triangles  [][3]int
+	Normals    []Vector // Stored normal of each triangle.
+	Attributes []uint16 // Attribute byte count of each triangle.
+	Facets     []int    // Index into 'Solid.Facets' of the facet each triangle was built from.
+}
+
+// NewMesh will build a 'Mesh' from the solid, welding every vertex within the tolerance
+// of an earlier vertex into that vertex. A tolerance of 0 only welds vertices with identical
+// coordinates. Facets without 3 vertices are dropped, which shifts the triangles after them,
+// so 'Mesh.Facets' maps each triangle back to its facet. Facets collapsed by welding are kept.
+func NewMesh(s Solid, tolerance float64) Mesh {
+	m := Mesh{
+		Name:       s.Name,
+		Header:     s.Header,
+		Triangles:  make([][3]int, 0, len(s.Facets)),
+		Normals:    make([]Vector, 0, len(s.Facets)),
+		Attributes: make([]uint16, 0, len(s.Facets)),
+		Facets:     make([]int, 0, len(s.Facets)),
+	}
+
+	w := newWelder(tolerance)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			continue
+		}
+
+		var t [3]int
+		for j, v := range f.Vertices {
+			t[j] = w.index(v, &m.Vertices)
+		}
+		m.Triangles = append(m.Triangles, t)
+		m.Normals = append(m.Normals, f.Normal)
+		m.Attributes = append(m.Attributes, f.Attribute)
+		m.Facets = append(m.Facets, i)
+	}
+
+	return m
+}
+
+// Solid will convert the mesh back into a 'Solid' with one facet per triangle.
+func (m Mesh) Solid() Solid {
+	s := Solid{
+		Name:   m.Name,
+		Header: m.Header,
+		Facets: make([]Facet, len(m.Triangles)),
+	}
+	for i, t := range m.Triangles {
+		f := Facet{
+			Vertices: []Vector{m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]},
+		}
+		if i < len(m.Normals) {
+			f.Normal = m.Normals[i]
+		}
+		if i < len(m.Attributes) {
+			f.Attribute = m.Attributes[i]
+		}
+		s.Facets[i] = f
+	}
+	return s
+}
+
+// welder represents our lookup of already seen vertices. With a positive tolerance
+// vertices are bucketed in a grid of cells as wide as the tolerance, so only the
+// neighboring cells need to be searched for a vertex to weld to.
+type welder struct {
+	tolerance float64
+	exact     map[Vector]int
+	cells     map[[3]int64][]int
+}
+
+func newWelder(tolerance float64) *welder {
+	return &welder{
+		tolerance: tolerance,
+		exact:     map[Vector]int{},
+		cells:     map[[3]int64][]int{},
+	}
+}
+
+// index will return the index of the vertex v welds to, appending v to vertices if none.
+func (w *welder) index(v Vector, vertices *[]Vector) int {
+	if i, ok := w.find(v, *vertices); ok {
+		return i
+	}
+
+	i := len(*vertices)
+	*vertices = append(*vertices, v)
+	if w.tolerance <= 0 {
+		w.exact[v] = i
+	} else {
+		cell := w.cell(v)
+		w.cells[cell] = append(w.cells[cell], i)
+	}
+	return i
+}
+
+// find will return the index of the vertex v welds to and whether there is one.
+func (w *welder) find(v Vector, vertices []Vector) (int, bool) {
+	if w.tolerance <= 0 {
+		i, ok := w.exact[v]
+		return i, ok
+	}
+
+	cell := w.cell(v)
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for dz := int64(-1); dz <= 1; dz++ {
+				for _, i := range w.cells[[3]int64{cell[0] + dx, cell[1] + dy, cell[2] + dz}] {
+					if vertices[i].sub(v).length() <= w.tolerance {
+						return i, true
+					}
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// cell will return the grid cell holding v.
+func (w *welder) cell(v Vector) [3]int64 {
+	return [3]int64{
+		int64(math.Floor(v.X / w.tolerance)),
+		int64(math.Floor(v.Y / w.tolerance)),
+		int64(math.Floor(v.Z / w.tolerance)),
+	}
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMesh(t *testing.T) {
+	// Arrange
+	var (
+		cube      = unitCube()
+		perturbed = unitCube()
+	)
+	perturbed.Facets[0].Vertices[0] = Vector{X: 1e-7, Y: -1e-7, Z: 0}
+	perturbed.Facets = append(perturbed.Facets, Facet{Vertices: []Vector{{X: 1}}})
+
+	tcs := map[string]struct {
+		solid     Solid
+		tolerance float64
+		vertices  int
+		triangles int
+	}{
+		"exact cube": {
+			solid:     cube,
+			tolerance: 0,
+			vertices:  8,
+			triangles: 12,
+		},
+		"perturbed cube without tolerance": {
+			solid:     perturbed,
+			tolerance: 0,
+			vertices:  9,
+			triangles: 12,
+		},
+		"perturbed cube with tolerance": {
+			solid:     perturbed,
+			tolerance: 1e-6,
+			vertices:  8,
+			triangles: 12,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			m := NewMesh(tc.solid, tc.tolerance)
+
+			// Assert
+			require.Len(t, m.Vertices, tc.vertices)
+			require.Len(t, m.Triangles, tc.triangles)
+			require.Len(t, m.Normals, tc.triangles)
+			require.Len(t, m.Attributes, tc.triangles)
+		})
+	}
+}
+
+func TestNewMeshFacets(t *testing.T) {
+	// Arrange
+	var (
+		cube = unitCube()
+		s    = Solid{Facets: []Facet{{Vertices: []Vector{{X: 1}}}, cube.Facets[0], {}, cube.Facets[1]}}
+	)
+
+	// Act
+	m := NewMesh(s, 0)
+
+	// Assert
+	require.Equal(t, []int{1, 3}, m.Facets)
+}
+
+func TestMeshSolid(t *testing.T) {
+	// Arrange
+	cube := unitCube()
+	cube.Facets[3].Attribute = 9
+
+	// Act
+	out := NewMesh(cube, 0).Solid()
+
+	// Assert
+	require.Equal(t, cube, out)
+}
@@ -1,14 +1,49 @@
 package parser
 
-import (
-	"fmt"
-	"math"
-	"strings"
-)
+import "math"
+
+// Model represents every solid found in an STL file.
+type Model struct {
+	Solids []Solid
+}
+
+// Triangles will return the total number of facets across every solid.
+func (m Model) Triangles() int {
+	var n int
+	for i := 0; i < len(m.Solids); i++ {
+		n += len(m.Solids[i].Facets)
+	}
+	return n
+}
+
+// SurfaceArea will calculate and return the total surface area across every solid.
+func (m Model) SurfaceArea() float64 {
+	var surfaceArea float64
+	for i := 0; i < len(m.Solids); i++ {
+		surfaceArea += m.Solids[i].SurfaceArea()
+	}
+	return surfaceArea
+}
+
+// BoundingBox will calculate and return the min and max vertices
+// representing the bounding box enclosing every solid.
+func (m Model) BoundingBox() (Vector, Vector) {
+	var (
+		minV = Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)}
+		maxV = Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)}
+	)
+	for i := 0; i < len(m.Solids); i++ {
+		sMin, sMax := m.Solids[i].BoundingBox()
+		minV = Vector{X: min(minV.X, sMin.X), Y: min(minV.Y, sMin.Y), Z: min(minV.Z, sMin.Z)}
+		maxV = Vector{X: max(maxV.X, sMax.X), Y: max(maxV.Y, sMax.Y), Z: max(maxV.Z, sMax.Z)}
+	}
+	return minV, maxV
+}
 
 // Solid represents the main object represented by the STL file.
 type Solid struct {
 	Name   string
+	Header []byte // Raw 80 byte header, only set when read from a binary STL file.
 	Facets []Facet
 }
 
@@ -64,18 +99,23 @@ func (s Solid) SurfaceArea() float64 {
 		},
 	}
 */
+
+// CheckDuplicates will report whether two facets of the solid have exactly the same
+// vertices in the same order, see 'Solid.FindDuplicates' for the groups.
 func (s Solid) CheckDuplicates() bool {
-	duplicatesMap := map[string]bool{}
+	return len(s.FindDuplicates(DuplicateOptions{})) > 0
+}
+
+// Volume will calculate and return the volume enclosed by the solid as the sum of the
+// signed volumes of the tetrahedra formed by each facet and the origin. The volume is
+// negative when the facets are wound inward. The returned bool reports whether the
+// result can be trusted, which requires a closed mesh with a consistent orientation.
+func (s Solid) Volume() (float64, bool) {
+	var volume float64
 	for i := 0; i < len(s.Facets); i++ {
-		f := s.Facets[i]
-		facetHash := f.toHash()
-		if _, ok := duplicatesMap[facetHash]; ok {
-			return true
-		} else {
-			duplicatesMap[facetHash] = true
-		}
+		volume += s.Facets[i].signedVolume()
 	}
-	return false
+	return volume, orientedClosed(s.Facets)
 }
 
 // BoundingBox will calculate and return the min and max vertices
@@ -92,7 +132,7 @@ func (s Solid) BoundingBox() (Vector, Vector) {
 		)
 		minX = min(min(minX, p1.X), min(p2.X, p3.X))
 		minY = min(min(minY, p1.Y), min(p2.Y, p3.Y))
-		minZ = min(min(minZ, p1.Z), min(p3.Z, p3.Z))
+		minZ = min(min(minZ, p1.Z), min(p2.Z, p3.Z))
 		maxX = max(max(maxX, p1.X), max(p2.X, p3.X))
 		maxY = max(max(maxY, p1.Y), max(p2.Y, p3.Y))
 		maxZ = max(max(maxZ, p1.Z), max(p2.Z, p3.Z))
@@ -104,20 +144,9 @@ func (s Solid) BoundingBox() (Vector, Vector) {
 // Facet represents a component of the solid, with a normal and vertices.
 // Represented as a triangle.
 type Facet struct {
-	Normal   Vector
-	Vertices []Vector
-}
-
-// toHash will convert the facets vertices into a single string
-// seperated by ':'.
-func (f Facet) toHash() string {
-	var hash string
-	for i := 0; i < len(f.Vertices); i++ {
-		v := f.Vertices[i]
-		hash += fmt.Sprintf("%.1f%.1f%.1f:", v.X, v.Y, v.Z)
-	}
-
-	return strings.TrimSuffix(hash, ":")
+	Normal    Vector
+	Vertices  []Vector
+	Attribute uint16 // Attribute byte count, only set when read from a binary STL file.
 }
 
 // Area will calculate and return the area of the facet.
@@ -126,6 +155,14 @@ func (f Facet) Area() float64 {
 		return 0
 	}
 
+	// Calculate Area.
+	return .5 * f.crossProduct().length()
+}
+
+// crossProduct will calculate and return the cross product of the edges v2-v1 and v3-v1
+// of the facet. Its length is twice the area of the facet and it points along the
+// normal given by the winding of the vertices.
+func (f Facet) crossProduct() Vector {
 	triangle := struct {
 		v1, v2, v3 Vector
 	}{
@@ -135,17 +172,24 @@ func (f Facet) Area() float64 {
 	}
 
 	// Subtract triangle vertices v2-v1 and v3-v1.
-	rv1 := Vector{X: triangle.v2.X - triangle.v1.X, Y: triangle.v2.Y - triangle.v1.Y, Z: triangle.v2.Z - triangle.v1.Z}
-	rv2 := Vector{X: triangle.v3.X - triangle.v1.X, Y: triangle.v3.Y - triangle.v1.Y, Z: triangle.v3.Z - triangle.v1.Z}
+	rv1 := triangle.v2.sub(triangle.v1)
+	rv2 := triangle.v3.sub(triangle.v1)
 
 	// Take cross product	of resulting two vectors.
 	// (rv1.y*rv2.z) - (rv1.z*rv2.y)
 	// (rv1.z*rv2.x) - (rv1.x*rv2.z)
 	// (rv1.x*rv2.y) - (rv1.y*rv2.x)
-	cp := Vector{X: (rv1.Y * rv2.Z) - (rv1.Z * rv2.Y), Y: (rv1.Z * rv2.X) - (rv1.X * rv2.Z), Z: (rv1.X * rv2.Y) - (rv1.Y * rv2.X)}
+	return rv1.cross(rv2)
+}
 
-	// Calculate Area.
-	return .5 * math.Sqrt(cp.X*cp.X+cp.Y*cp.Y+cp.Z*cp.Z)
+// signedVolume will calculate and return the signed volume of the tetrahedron
+// formed by the facet and the origin. Summed over a closed mesh this gives the
+// volume enclosed by the mesh, positive when the facets are wound outward.
+func (f Facet) signedVolume() float64 {
+	if len(f.Vertices) != 3 {
+		return 0
+	}
+	return f.Vertices[0].dot(f.Vertices[1].cross(f.Vertices[2])) / 6
 }
 
 // NewFacet will create a 'Facet' with instantiated vertices of length 3
@@ -161,6 +205,42 @@ type Vector struct {
 	X, Y, Z float64
 }
 
+// add will return the vector v+o.
+func (v Vector) add(o Vector) Vector {
+	return Vector{X: v.X + o.X, Y: v.Y + o.Y, Z: v.Z + o.Z}
+}
+
+// sub will return the vector v-o.
+func (v Vector) sub(o Vector) Vector {
+	return Vector{X: v.X - o.X, Y: v.Y - o.Y, Z: v.Z - o.Z}
+}
+
+// scale will return the vector v multiplied by k.
+func (v Vector) scale(k float64) Vector {
+	return Vector{X: v.X * k, Y: v.Y * k, Z: v.Z * k}
+}
+
+// length will return the euclidean length of v.
+func (v Vector) length() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// cross will return the cross product of v and o.
+func (v Vector) cross(o Vector) Vector {
+	return Vector{X: (v.Y * o.Z) - (v.Z * o.Y), Y: (v.Z * o.X) - (v.X * o.Z), Z: (v.X * o.Y) - (v.Y * o.X)}
+}
+
+// dot will return the dot product of v and o.
+func (v Vector) dot(o Vector) float64 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+// isFinite will report whether no component of v is 'NaN' or infinite.
+func (v Vector) isFinite() bool {
+	return !math.IsNaN(v.X) && !math.IsNaN(v.Y) && !math.IsNaN(v.Z) &&
+		!math.IsInf(v.X, 0) && !math.IsInf(v.Y, 0) && !math.IsInf(v.Z, 0)
+}
+
 // min will calculate and return the min of two given values.
 func min(a, b float64) float64 {
 	if a < b {
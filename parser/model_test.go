@@ -95,6 +95,28 @@ func TestBoundingBox(t *testing.T) {
 	require.Equal(t, expectedMax, outMax)
 }
 
+func TestBoundingBoxMiddleVertex(t *testing.T) {
+	// Arrange
+	s := Solid{
+		Facets: []Facet{
+			{
+				Vertices: []Vector{
+					{X: 0, Y: 0, Z: 0},
+					{X: -1, Y: -2, Z: -3},
+					{X: 1, Y: 2, Z: 3},
+				},
+			},
+		},
+	}
+
+	// Act
+	outMin, outMax := s.BoundingBox()
+
+	// Assert
+	require.Equal(t, Vector{X: -1, Y: -2, Z: -3}, outMin)
+	require.Equal(t, Vector{X: 1, Y: 2, Z: 3}, outMax)
+}
+
 func TestArea(t *testing.T) {
 	// Arrange
 	var (
@@ -121,26 +143,6 @@ func TestArea(t *testing.T) {
 	require.Equal(t, expected, out)
 }
 
-func TestToHash(t *testing.T) {
-	// Arrange
-	var (
-		f = Facet{
-			Vertices: []Vector{
-				{X: 0, Y: 1, Z: 2},
-				{X: 0, Y: 1, Z: 2},
-				{X: 0, Y: 1, Z: 2},
-			},
-		}
-		expected = "0.01.02.0:0.01.02.0:0.01.02.0"
-	)
-
-	// Assert
-	h := f.toHash()
-
-	// Act
-	assert.Equal(t, expected, h)
-}
-
 func TestCheckDuplicates(t *testing.T) {
 	// Arrange
 	tcs := map[string]struct {
@@ -189,6 +191,27 @@ func TestCheckDuplicates(t *testing.T) {
 			},
 			expected: false,
 		},
+		"close but distinct scenario": {
+			solid: Solid{
+				Facets: []Facet{
+					{
+						Vertices: []Vector{
+							{X: 0.01, Y: 2, Z: 3},
+							{X: 4, Y: 5, Z: 6},
+							{X: 7, Y: 8, Z: 9},
+						},
+					},
+					{
+						Vertices: []Vector{
+							{X: 0.04, Y: 2, Z: 3},
+							{X: 4, Y: 5, Z: 6},
+							{X: 7, Y: 8, Z: 9},
+						},
+					},
+				},
+			},
+			expected: false,
+		},
 	}
 
 	for name, tc := range tcs {
@@ -201,3 +224,116 @@ func TestCheckDuplicates(t *testing.T) {
 		})
 	}
 }
+
+// unitCube returns a closed, outward wound cube spanning (0,0,0) to (1,1,1).
+func unitCube() Solid {
+	facet := func(n Vector, a, b, c Vector) Facet {
+		return Facet{Normal: n, Vertices: []Vector{a, b, c}}
+	}
+	return Solid{
+		Name: "cube",
+		Facets: []Facet{
+			facet(Vector{Z: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 0, Y: 1, Z: 0}, Vector{X: 1, Y: 1, Z: 0}),
+			facet(Vector{Z: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 1, Y: 1, Z: 0}, Vector{X: 1, Y: 0, Z: 0}),
+			facet(Vector{Z: 1}, Vector{X: 0, Y: 0, Z: 1}, Vector{X: 1, Y: 0, Z: 1}, Vector{X: 1, Y: 1, Z: 1}),
+			facet(Vector{Z: 1}, Vector{X: 0, Y: 0, Z: 1}, Vector{X: 1, Y: 1, Z: 1}, Vector{X: 0, Y: 1, Z: 1}),
+			facet(Vector{Y: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 1, Y: 0, Z: 0}, Vector{X: 1, Y: 0, Z: 1}),
+			facet(Vector{Y: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 1, Y: 0, Z: 1}, Vector{X: 0, Y: 0, Z: 1}),
+			facet(Vector{Y: 1}, Vector{X: 0, Y: 1, Z: 0}, Vector{X: 0, Y: 1, Z: 1}, Vector{X: 1, Y: 1, Z: 1}),
+			facet(Vector{Y: 1}, Vector{X: 0, Y: 1, Z: 0}, Vector{X: 1, Y: 1, Z: 1}, Vector{X: 1, Y: 1, Z: 0}),
+			facet(Vector{X: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 0, Y: 0, Z: 1}, Vector{X: 0, Y: 1, Z: 1}),
+			facet(Vector{X: -1}, Vector{X: 0, Y: 0, Z: 0}, Vector{X: 0, Y: 1, Z: 1}, Vector{X: 0, Y: 1, Z: 0}),
+			facet(Vector{X: 1}, Vector{X: 1, Y: 0, Z: 0}, Vector{X: 1, Y: 1, Z: 0}, Vector{X: 1, Y: 1, Z: 1}),
+			facet(Vector{X: 1}, Vector{X: 1, Y: 0, Z: 0}, Vector{X: 1, Y: 1, Z: 1}, Vector{X: 1, Y: 0, Z: 1}),
+		},
+	}
+}
+
+func TestModel(t *testing.T) {
+	// Arrange
+	var (
+		cube  = unitCube()
+		other = Solid{
+			Facets: []Facet{
+				{
+					Vertices: []Vector{
+						{X: -1, Y: 0, Z: 0},
+						{X: 0, Y: 3, Z: 0},
+						{X: 0, Y: 0, Z: 0},
+					},
+				},
+			},
+		}
+		m = Model{Solids: []Solid{cube, other}}
+	)
+
+	// Act
+	triangles := m.Triangles()
+	area := m.SurfaceArea()
+	outMin, outMax := m.BoundingBox()
+
+	// Assert
+	require.Equal(t, 13, triangles)
+	require.InDelta(t, 7.5, area, 1e-12)
+	require.Equal(t, Vector{X: -1, Y: 0, Z: 0}, outMin)
+	require.Equal(t, Vector{X: 1, Y: 3, Z: 1}, outMax)
+}
+
+// flipped returns the solid with the winding of every facet reversed.
+func flipped(s Solid) Solid {
+	out := Solid{Name: s.Name}
+	for _, f := range s.Facets {
+		out.Facets = append(out.Facets, Facet{
+			Normal:   Vector{X: -f.Normal.X, Y: -f.Normal.Y, Z: -f.Normal.Z},
+			Vertices: []Vector{f.Vertices[0], f.Vertices[2], f.Vertices[1]},
+		})
+	}
+	return out
+}
+
+func TestVolume(t *testing.T) {
+	// Arrange
+	var (
+		cube     = unitCube()
+		open     = Solid{Facets: cube.Facets[1:]}
+		inverted = flipped(cube)
+		mixed    = Solid{Facets: append([]Facet{flipped(cube).Facets[0]}, cube.Facets[1:]...)}
+	)
+	tcs := map[string]struct {
+		solid    Solid
+		expected float64
+		trusted  bool
+	}{
+		"closed cube": {
+			solid:    cube,
+			expected: 1,
+			trusted:  true,
+		},
+		"inward wound cube": {
+			solid:    inverted,
+			expected: -1,
+			trusted:  true,
+		},
+		"open cube": {
+			solid:    open,
+			expected: 1,
+			trusted:  false,
+		},
+		"inconsistent winding": {
+			solid:    mixed,
+			expected: 1,
+			trusted:  false,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			volume, trusted := tc.solid.Volume()
+
+			// Assert
+			require.InDelta(t, tc.expected, volume, 1e-12)
+			require.Equal(t, tc.trusted, trusted)
+		})
+	}
+}
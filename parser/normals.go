@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"math"
+	"strings"
+)
+
+// Defaults used by 'Solid.CheckNormals' when the options are not positive.
+const (
+	DefaultUnitTolerance = 1e-4          // Allowed difference between the length of a normal and 1.
+	DefaultNormalAngle   = math.Pi / 180 // Allowed angle in radians between a stored and a geometric normal.
+)
+
+// NormalProblem represents the ways a stored normal can be wrong, combined as bit flags.
+type NormalProblem uint8
+
+// Problems reported by 'Solid.CheckNormals'.
+const (
+	NormalZero     NormalProblem = 1 << iota // The stored normal is the zero vector.
+	NormalNonUnit                            // The stored normal does not have a length of 1.
+	NormalMismatch                           // The stored normal disagrees with the winding of the vertices.
+)
+
+// Has returns whether p holds every problem of o.
+func (p NormalProblem) Has(o NormalProblem) bool {
+	return p&o == o
+}
+
+// String returns the names of the problems separated by '|'.
+func (p NormalProblem) String() string {
+	var names []string
+	if p.Has(NormalZero) {
+		names = append(names, "zero")
+	}
+	if p.Has(NormalNonUnit) {
+		names = append(names, "non-unit")
+	}
+	if p.Has(NormalMismatch) {
+		names = append(names, "mismatch")
+	}
+	return strings.Join(names, "|")
+}
+
+// NormalOptions represents the thresholds used by 'Solid.CheckNormals'.
+type NormalOptions struct {
+	UnitTolerance float64 // Allowed difference between the length of a normal and 1.
+	MaxAngle      float64 // Allowed angle in radians between a stored and a geometric normal.
+}
+
+// NormalIssue represents a facet whose stored normal has problems.
+type NormalIssue struct {
+	Facet   int           // Index of the facet into 'Solid.Facets'.
+	Problem NormalProblem // Every problem found with the stored normal.
+	Angle   float64       // Angle in radians between the stored and geometric normal, 0 when either is zero.
+}
+
+// GeometricNormal will calculate and return the unit normal of the facet given by the
+// right hand rule over its vertices, or the zero vector for a degenerate facet.
+func (f Facet) GeometricNormal() Vector {
+	if len(f.Vertices) != 3 {
+		return Vector{}
+	}
+
+	c := f.crossProduct()
+	l := c.length()
+	if l == 0 {
+		return Vector{}
+	}
+	return c.scale(1 / l)
+}
+
+// CheckNormals will return an issue for every facet whose stored normal is zero, is not of
+// unit length, or points more than the allowed angle away from 'Facet.GeometricNormal'.
+// The winding of a degenerate facet is unknown so its normal is never reported as a mismatch.
+func (s Solid) CheckNormals(opts NormalOptions) []NormalIssue {
+	if opts.UnitTolerance <= 0 {
+		opts.UnitTolerance = DefaultUnitTolerance
+	}
+	if opts.MaxAngle <= 0 {
+		opts.MaxAngle = DefaultNormalAngle
+	}
+
+	var issues []NormalIssue
+	for i := 0; i < len(s.Facets); i++ {
+		var (
+			f     = s.Facets[i]
+			issue = NormalIssue{Facet: i}
+			l     = f.Normal.length()
+		)
+		if l == 0 {
+			issue.Problem |= NormalZero
+		} else {
+			if math.Abs(l-1) > opts.UnitTolerance {
+				issue.Problem |= NormalNonUnit
+			}
+
+			g := f.GeometricNormal()
+			if g != (Vector{}) {
+				cos := math.Max(-1, math.Min(1, f.Normal.dot(g)/l))
+				issue.Angle = math.Acos(cos)
+				if issue.Angle > opts.MaxAngle {
+					issue.Problem |= NormalMismatch
+				}
+			}
+		}
+
+		if issue.Problem != 0 {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// RecomputeNormals will return a copy of the solid with every stored normal
+// overwritten by 'Facet.GeometricNormal'.
+func (s Solid) RecomputeNormals() Solid {
+	out := Solid{Name: s.Name, Header: s.Header, Facets: make([]Facet, len(s.Facets))}
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		f.Normal = f.GeometricNormal()
+		out.Facets[i] = f
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometricNormal(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		facet    Facet
+		expected Vector
+	}{
+		"counter clockwise": {
+			facet:    Facet{Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 2, Y: 0, Z: 0}, {X: 0, Y: 2, Z: 0}}},
+			expected: Vector{X: 0, Y: 0, Z: 1},
+		},
+		"clockwise": {
+			facet:    Facet{Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 2, Z: 0}, {X: 2, Y: 0, Z: 0}}},
+			expected: Vector{X: 0, Y: 0, Z: -1},
+		},
+		"collinear": {
+			facet:    Facet{Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 2, Y: 0, Z: 0}}},
+			expected: Vector{},
+		},
+		"missing vertex": {
+			facet:    Facet{Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}}},
+			expected: Vector{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := tc.facet.GeometricNormal()
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestCheckNormals(t *testing.T) {
+	// Arrange
+	var (
+		a    = Vector{X: 0, Y: 0, Z: 0}
+		b    = Vector{X: 1, Y: 0, Z: 0}
+		c    = Vector{X: 0, Y: 1, Z: 0}
+		tilt = Vector{X: math.Sin(0.1), Y: 0, Z: math.Cos(0.1)}
+		s    = Solid{
+			Facets: []Facet{
+				{Normal: Vector{Z: 1}, Vertices: []Vector{a, b, c}},
+				{Normal: Vector{}, Vertices: []Vector{a, b, c}},
+				{Normal: Vector{Z: 2}, Vertices: []Vector{a, b, c}},
+				{Normal: Vector{Z: -1}, Vertices: []Vector{a, b, c}},
+				{Normal: Vector{Z: -3}, Vertices: []Vector{a, b, c}},
+				{Normal: tilt, Vertices: []Vector{a, b, c}},
+				{Normal: Vector{Z: -1}, Vertices: []Vector{a, b, b}},
+			},
+		}
+	)
+	tcs := map[string]struct {
+		opts     NormalOptions
+		expected []NormalIssue
+	}{
+		"defaults": {
+			opts: NormalOptions{},
+			expected: []NormalIssue{
+				{Facet: 1, Problem: NormalZero},
+				{Facet: 2, Problem: NormalNonUnit},
+				{Facet: 3, Problem: NormalMismatch, Angle: math.Pi},
+				{Facet: 4, Problem: NormalNonUnit | NormalMismatch, Angle: math.Pi},
+				{Facet: 5, Problem: NormalMismatch, Angle: 0.1},
+			},
+		},
+		"wide angle and tolerance": {
+			opts: NormalOptions{UnitTolerance: 2, MaxAngle: 0.2},
+			expected: []NormalIssue{
+				{Facet: 1, Problem: NormalZero},
+				{Facet: 3, Problem: NormalMismatch, Angle: math.Pi},
+				{Facet: 4, Problem: NormalMismatch, Angle: math.Pi},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := s.CheckNormals(tc.opts)
+
+			// Assert
+			require.Len(t, out, len(tc.expected))
+			for i, e := range tc.expected {
+				require.Equal(t, e.Facet, out[i].Facet)
+				require.Equal(t, e.Problem, out[i].Problem, "facet %d", e.Facet)
+				require.InDelta(t, e.Angle, out[i].Angle, 1e-9, "facet %d", e.Facet)
+			}
+		})
+	}
+}
+
+func TestNormalProblemString(t *testing.T) {
+	// Assert
+	require.Equal(t, "zero", NormalZero.String())
+	require.Equal(t, "non-unit|mismatch", (NormalNonUnit | NormalMismatch).String())
+}
+
+func TestRecomputeNormals(t *testing.T) {
+	// Arrange
+	var (
+		cube = unitCube()
+		s    = Solid{Name: cube.Name}
+	)
+	for _, f := range cube.Facets {
+		s.Facets = append(s.Facets, Facet{Vertices: f.Vertices})
+	}
+
+	// Act
+	out := s.RecomputeNormals()
+
+	// Assert
+	require.Equal(t, cube, out)
+	require.Empty(t, out.CheckNormals(NormalOptions{}))
+	require.Len(t, s.CheckNormals(NormalOptions{}), 12)
+}
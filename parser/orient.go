@@ -0,0 +1,129 @@
+package parser
+
+// OrientReport represents the changes made by 'Solid.Orient'.
+type OrientReport struct {
+	Components int   // Number of groups of facets connected through edges shared by exactly two facets.
+	Closed     int   // Number of components forming a closed shell, each oriented outward.
+	Flipped    []int // Indices into 'Solid.Facets' of every facet whose winding was reversed, in ascending order.
+	Conflicts  int   // Number of edges whose facets could not agree on a winding, as in a Mobius strip.
+}
+
+// incidence represents a facet using an edge, and whether it runs the edge in the
+// direction of its undirected key.
+type incidence struct {
+	facet   int
+	forward bool
+}
+
+// Orient will return a copy of the solid where the facets of each connected component share a
+// consistent winding, outward for a closed component, along with a report of the changes.
+func (s Solid) Orient() (Solid, OrientReport) {
+	edges := map[edge][]incidence{}
+	for i := 0; i < len(s.Facets); i++ {
+		if len(s.Facets[i].Vertices) != 3 {
+			continue
+		}
+		for _, e := range facetEdges(s.Facets[i]) {
+			if e.a == e.b {
+				continue
+			}
+			key := e.undirected()
+			edges[key] = append(edges[key], incidence{facet: i, forward: key == e})
+		}
+	}
+
+	// Winding is propagated across edges shared by exactly two facets, so non-manifold
+	// edges do not connect components.
+	var (
+		report  OrientReport
+		visited = make([]bool, len(s.Facets))
+		flip    = make([]bool, len(s.Facets))
+	)
+	for seed := 0; seed < len(s.Facets); seed++ {
+		if visited[seed] || len(s.Facets[seed].Vertices) != 3 {
+			continue
+		}
+		report.Components++
+
+		var (
+			component = []int{seed}
+			closed    = true
+		)
+		visited[seed] = true
+		for q := 0; q < len(component); q++ {
+			i := component[q]
+			for _, e := range facetEdges(s.Facets[i]) {
+				if e.a == e.b {
+					continue
+				}
+				shared := edges[e.undirected()]
+				if len(shared) != 2 {
+					closed = false
+					continue
+				}
+
+				var self, other incidence
+				if shared[0].facet == i {
+					self, other = shared[0], shared[1]
+				} else {
+					self, other = shared[1], shared[0]
+				}
+
+				// Neighbors are consistent when they run the shared edge in opposite directions.
+				want := flip[i] != (self.forward == other.forward)
+				if !visited[other.facet] {
+					visited[other.facet] = true
+					flip[other.facet] = want
+					component = append(component, other.facet)
+				} else if flip[other.facet] != want && i < other.facet {
+					report.Conflicts++
+				}
+			}
+		}
+
+		var (
+			volume  float64
+			flipped int
+		)
+		for _, i := range component {
+			v := s.Facets[i].signedVolume()
+			if flip[i] {
+				v = -v
+				flipped++
+			}
+			volume += v
+		}
+
+		// An open component has no inside, so it keeps the winding of most of its facets.
+		invert := 2*flipped > len(component)
+		if closed {
+			report.Closed++
+			invert = volume < 0
+		}
+		if invert {
+			for _, i := range component {
+				flip[i] = !flip[i]
+			}
+		}
+	}
+
+	out := Solid{Name: s.Name, Header: s.Header, Facets: make([]Facet, len(s.Facets))}
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if flip[i] {
+			report.Flipped = append(report.Flipped, i)
+			f = f.reversed()
+		}
+		out.Facets[i] = f
+	}
+	return out, report
+}
+
+// reversed will return the facet with its winding and stored normal reversed.
+func (f Facet) reversed() Facet {
+	return Facet{
+		Normal:    Vector{}.sub(f.Normal),
+		Vertices:  []Vector{f.Vertices[0], f.Vertices[2], f.Vertices[1]},
+		Attribute: f.Attribute,
+	}
+}
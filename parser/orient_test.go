@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrient(t *testing.T) {
+	// Arrange
+	var (
+		cube  = unitCube()
+		mixed = unitCube()
+		moved = unitCube()
+		a     = Vector{X: 0, Y: 0, Z: 0}
+		b     = Vector{X: 1, Y: 0, Z: 0}
+		c     = Vector{X: 1, Y: 1, Z: 0}
+		d     = Vector{X: 0, Y: 1, Z: 0}
+	)
+	mixed.Facets[3] = mixed.Facets[3].reversed()
+	mixed.Facets[7] = mixed.Facets[7].reversed()
+	for i := range moved.Facets {
+		for j := range moved.Facets[i].Vertices {
+			moved.Facets[i].Vertices[j].X += 5
+		}
+	}
+
+	tcs := map[string]struct {
+		solid          Solid
+		expected       Solid
+		expectedReport OrientReport
+	}{
+		"consistent": {
+			solid:          cube,
+			expected:       cube,
+			expectedReport: OrientReport{Components: 1, Closed: 1},
+		},
+		"inconsistent": {
+			solid:          mixed,
+			expected:       cube,
+			expectedReport: OrientReport{Components: 1, Closed: 1, Flipped: []int{3, 7}},
+		},
+		"inward": {
+			solid:          flipped(cube),
+			expected:       cube,
+			expectedReport: OrientReport{Components: 1, Closed: 1, Flipped: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		},
+		"open": {
+			solid: Solid{Facets: []Facet{
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{a, d, c}},
+				{Vertices: []Vector{d, c, {X: 0, Y: 2, Z: 0}}},
+			}},
+			expected: Solid{Facets: []Facet{
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{a, c, d}},
+				{Vertices: []Vector{d, c, {X: 0, Y: 2, Z: 0}}},
+			}},
+			expectedReport: OrientReport{Components: 1, Flipped: []int{1}},
+		},
+		"components": {
+			solid:    Solid{Facets: append(append([]Facet{}, cube.Facets...), flipped(moved).Facets...)},
+			expected: Solid{Facets: append(append([]Facet{}, cube.Facets...), moved.Facets...)},
+			expectedReport: OrientReport{
+				Components: 2,
+				Closed:     2,
+				Flipped:    []int{12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out, report := tc.solid.Orient()
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+			require.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
+
+func TestOrientVolume(t *testing.T) {
+	// Arrange
+	s := unitCube()
+	s.Facets[0] = s.Facets[0].reversed()
+	_, ok := s.Volume()
+	require.False(t, ok)
+
+	// Act
+	out, _ := s.Orient()
+
+	// Assert
+	volume, ok := out.Volume()
+	require.True(t, ok)
+	require.InDelta(t, 1, volume, 1e-12)
+}
+
+func TestOrientConflict(t *testing.T) {
+	// Arrange
+	var (
+		a0 = Vector{X: 0, Y: 0, Z: 0}
+		a1 = Vector{X: 1, Y: 0, Z: 0}
+		a2 = Vector{X: 2, Y: 0, Z: 0}
+		b0 = Vector{X: 0, Y: 1, Z: 0}
+		b1 = Vector{X: 1, Y: 1, Z: 0}
+		b2 = Vector{X: 2, Y: 1, Z: 0}
+		// A strip of three quads whose last quad joins the first one after a half twist.
+		s = Solid{Facets: []Facet{
+			{Vertices: []Vector{a0, b0, b1}},
+			{Vertices: []Vector{a0, b1, a1}},
+			{Vertices: []Vector{a1, b1, b2}},
+			{Vertices: []Vector{a1, b2, a2}},
+			{Vertices: []Vector{a2, b2, a0}},
+			{Vertices: []Vector{a2, a0, b0}},
+		}}
+	)
+
+	// Act
+	_, report := s.Orient()
+
+	// Assert
+	require.Equal(t, 1, report.Components)
+	require.Equal(t, 0, report.Closed)
+	require.Equal(t, 1, report.Conflicts)
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/lenguti/STLParser/lexer"
 	"github.com/pkg/errors"
@@ -10,54 +11,113 @@ import (
 
 type scanner interface {
 	Scan() (lexer.Token, string)
+	Pos() lexer.Position
 }
 
 // Parser represnts our main parsing object for reading contents of an STL file.
 type Parser struct {
-	s scanner
-	b struct {
-		tok lexer.Token // Last read token.
-		val string      // Last read value.
-		n   int         // Buffer size, max of 1.
+	s    scanner
+	opts options
+	b    struct {
+		tok lexer.Token    // Last read token.
+		val string         // Last read value.
+		pos lexer.Position // Position of last read token.
+		n   int            // Buffer size, max of 1.
+	}
+
+	name      string // Name of the solid, read by 'Name'.
+	started   bool   // Whether the 'solid name' line has been read.
+	headerErr error  // Error found while reading the 'solid name' line.
+	err       error  // First error returned by 'Next', including 'io.EOF'.
+
+	closed      bool    // Whether 'endsolid' has been read.
+	diagnostics []error // Errors skipped over when recovering.
+}
+
+// Option represents a configuration applied to a 'Parser' on creation.
+type Option func(*options)
+
+// options represents the configuration collected from every 'Option'.
+type options struct {
+	scanner []lexer.Option // Options passed through to the underlying 'lexer.Scanner'.
+	recover bool           // Whether malformed facets are skipped instead of aborting.
+	strict  bool           // Whether only the canonical layout of the grammar is accepted.
+}
+
+// WithNonFinite will make the parser accept 'inf' and 'nan' values in vectors.
+func WithNonFinite() Option {
+	return func(o *options) {
+		o.scanner = append(o.scanner, lexer.WithNonFinite())
+	}
+}
+
+// WithRecovery will make the parser skip any malformed facet instead of aborting.
+// Each skipped facet is recorded and available through 'Parser.Diagnostics', and
+// 'Parser.Parse' returns the facets that could be recovered.
+func WithRecovery() Option {
+	return func(o *options) {
+		o.recover = true
+	}
+}
+
+// WithStrict will make the parser only accept the canonical layout of the grammar:
+// one statement per line, a single word name after 'solid' repeated after 'endsolid'
+// and nothing but further solids after 'endsolid'. By default the parser accepts
+// the variations written by common exporters such as empty or multi word names,
+// blank lines, statements split across lines, a missing or different name after
+// 'endsolid' and trailing content.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
 	}
 }
 
 // New Returns a pointer to a 'Parser' with an
 // instantiated 'lexer.Scanner'.
-func New(r io.Reader) *Parser {
+func New(r io.Reader, opts ...Option) *Parser {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return &Parser{
-		s: lexer.NewScanner(r),
+		s:    lexer.NewScanner(r, o.scanner...),
+		opts: o,
 	}
 }
 
 // parseVector will parse a vector of the form:
 // '0 0 0' representing the X, Y, and Z of a vector.
-// Will return an error if more than 3 points are found.
+// Will return an error if more or less than 3 points are found.
 func (p *Parser) parseVector() (Vector, error) {
 	var (
 		v      Vector
-		i      int
 		points = make([]float64, 3)
 	)
-	// Since we know we are parsing a vector, loop through x, y, and z values until we reach end of line.
-	for tok, val := p.scanIgnoreWhitespace(); tok != lexer.NEWLINE; tok, val = p.scanIgnoreWhitespace() {
-		if tok != lexer.INTEGER {
-			return v, errors.Errorf("parse vector: found [%v], expected 'integer'", val)
-		}
-
-		// If we recieve more than three points then this is an invalid vector.
-		if i > 2 {
-			return v, errors.New("parse vector: too many points in vector")
+	// Since we know we are parsing a vector, read the x, y, and z values.
+	for i := 0; i < 3; i++ {
+		tok, val := p.scanToken()
+		if tok != lexer.INTEGER && tok != lexer.FLOAT {
+			// Reaching the end of the line or the next statement means points are missing.
+			if tok == lexer.NEWLINE || isKeyword(tok) {
+				return v, p.syntaxError("parse vector", ErrTooFewPoints, "'number'")
+			}
+			return v, p.syntaxError("parse vector", ErrUnexpectedToken, "'number'")
 		}
 
 		floatVal, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			return v, errors.WithMessage(err, "parse vector: unable to parse into float")
+			return v, p.syntaxError("parse vector", ErrInvalidNumber, "'number'")
 		}
 		points[i] = floatVal
-		i++
+	}
+
+	// If we recieve more than three points then this is an invalid vector.
+	tok, _ := p.scanToken()
+	if tok == lexer.INTEGER || tok == lexer.FLOAT {
+		return v, p.syntaxError("parse vector", ErrTooManyPoints, "'newline'")
 	}
 	p.unscan() // Put back last read token and value into buffer to be read again.
+
 	v.X = points[0]
 	v.Y = points[1]
 	v.Z = points[2]
@@ -66,21 +126,21 @@ func (p *Parser) parseVector() (Vector, error) {
 
 // parseVertices will parse vertices of the form:
 // 'vertex 0 0 0' representing the 3 vertices of a triangle.
-// Will return an error if more than 3 vertices are found.
+// Will return an error if more or less than 3 vertices are found.
 func (p *Parser) parseVertices() ([]Vector, error) {
 	var (
 		i  int
 		vs = make([]Vector, 3)
 	)
 	// Since we know we are parsing a the vertices of a facet, loop through each vertice until we reach end loop.
-	for tok, val := p.scanIgnoreWhitespace(); tok != lexer.ENDLOOP; tok, val = p.scanIgnoreWhitespace() {
+	for tok, _ := p.scanToken(); tok != lexer.ENDLOOP; tok, _ = p.scanToken() {
 		if tok != lexer.VERTEX {
-			return vs, errors.Errorf("parse vertices: found [%v], expected 'vertex'", val)
+			return vs, p.syntaxError("parse vertices", ErrUnexpectedToken, "'vertex'")
 		}
 
 		// If we recieve more than three vertices then we know this is an invalid shape.
 		if i > 2 {
-			return vs, errors.New("parse verticies: too many vertices in facet")
+			return vs, p.syntaxError("parse vertices", ErrTooManyVertices, "'endloop'")
 		}
 
 		v, err := p.parseVector()
@@ -90,11 +150,13 @@ func (p *Parser) parseVertices() ([]Vector, error) {
 		vs[i] = v
 		i++
 
-		tok, val = p.scanIgnoreWhitespace()
-		if tok != lexer.NEWLINE {
-			return vs, errors.Errorf("parse verticies: found [%v], expected 'newline'", val)
+		if err := p.expectNewline("parse vertices"); err != nil {
+			return vs, err
 		}
 	}
+	if i < 3 {
+		return vs, p.syntaxError("parse vertices", ErrTooFewVertices, "'vertex'")
+	}
 	p.unscan() // Put back last read token and value into buffer to be read again.
 	return vs, nil
 }
@@ -110,14 +172,14 @@ func (p *Parser) parseVertices() ([]Vector, error) {
 // Representing a triangle and its given normal and vertices.
 func (p *Parser) parseFacet() (Facet, error) {
 	f := NewFacet()
-	tok, val := p.scanIgnoreWhitespace()
+	tok, _ := p.scanToken()
 	if tok != lexer.FACET {
-		return f, errors.Errorf("parse facet: found [%v], expected 'facet'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'facet'")
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, _ = p.scanToken()
 	if tok != lexer.NORMAL {
-		return f, errors.Errorf("parse facet: found [%v], expected 'normal'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'normal'")
 	}
 
 	normal, err := p.parseVector()
@@ -126,24 +188,22 @@ func (p *Parser) parseFacet() (Facet, error) {
 	}
 	f.Normal = normal
 
-	tok, val = p.scanIgnoreWhitespace()
-	if tok != lexer.NEWLINE {
-		return f, errors.Errorf("parse facet: found [%v], expected 'newline'", val)
+	if err := p.expectNewline("parse facet"); err != nil {
+		return f, err
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, _ = p.scanToken()
 	if tok != lexer.OUTER {
-		return f, errors.Errorf("parse facet: found [%v], expected 'outer'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'outer'")
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, _ = p.scanToken()
 	if tok != lexer.LOOP {
-		return f, errors.Errorf("parse facet: found [%v], expected 'loop'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'loop'")
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
-	if tok != lexer.NEWLINE {
-		return f, errors.Errorf("parse facet: found [%v], expected 'newline'", val)
+	if err := p.expectNewline("parse facet"); err != nil {
+		return f, err
 	}
 
 	vertices, err := p.parseVertices()
@@ -152,19 +212,18 @@ func (p *Parser) parseFacet() (Facet, error) {
 	}
 	f.Vertices = vertices
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, _ = p.scanToken()
 	if tok != lexer.ENDLOOP {
-		return f, errors.Errorf("parse facet: found [%v], expected 'endloop'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'endloop'")
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
-	if tok != lexer.NEWLINE {
-		return f, errors.Errorf("parse facet: found [%v], expected 'newline'", val)
+	if err := p.expectNewline("parse facet"); err != nil {
+		return f, err
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, _ = p.scanToken()
 	if tok != lexer.ENDFACET {
-		return f, errors.Errorf("parse facet: found [%v], expected 'endfacet'", val)
+		return f, p.syntaxError("parse facet", ErrUnexpectedToken, "'endfacet'")
 	}
 
 	return f, nil
@@ -175,54 +234,223 @@ func (p *Parser) parseFacet() (Facet, error) {
 // a proper STL object.
 func (p *Parser) Parse() (Solid, error) {
 	var s Solid
-	tok, val := p.scanIgnoreWhitespace()
+	name, err := p.Name()
+	if err != nil {
+		return s, err
+	}
+	s.Name = name
+
+	for {
+		f, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return s, err
+		}
+		s.Facets = append(s.Facets, f)
+	}
+
+	return s, nil
+}
+
+// ParseAll will parse every consecutive solid in the file,
+// for exporters that write one 'solid ... endsolid' block per body.
+func (p *Parser) ParseAll() (Model, error) {
+	var m Model
+	for {
+		s, err := p.Parse()
+		if err != nil {
+			return m, err
+		}
+		m.Solids = append(m.Solids, s)
+
+		more, err := p.More()
+		if err != nil {
+			return m, err
+		}
+		if !more {
+			return m, nil
+		}
+	}
+}
+
+// More will report whether another solid follows the current one, discarding any
+// of its facets that have not been read yet. When another solid follows the parser
+// is reset so 'Name' and 'Next' read the following solid.
+func (p *Parser) More() (bool, error) {
+	if _, err := p.Name(); err != nil {
+		return false, err
+	}
+	for p.err == nil {
+		_, _ = p.Next()
+	}
+	if p.err != io.EOF {
+		return false, p.err
+	}
+
+	tok, _ := p.scanIgnoreWhitespace()
+	for tok == lexer.NEWLINE {
+		tok, _ = p.scanIgnoreWhitespace()
+	}
+
+	switch tok {
+	case lexer.EOF:
+		return false, nil
+	case lexer.SOLID:
+		p.unscan()
+		p.name, p.started, p.headerErr, p.err, p.closed = "", false, nil, nil, false
+		return true, nil
+	}
+
+	// Unless strict, anything following the last solid is ignored.
+	if !p.opts.strict {
+		return false, nil
+	}
+	p.err = p.syntaxError("parse", ErrUnexpectedToken, "'solid'")
+	return false, p.err
+}
+
+// Name will read the 'solid name' line the first time it is called and return
+// the name of the solid, making it available before any facet is read.
+func (p *Parser) Name() (string, error) {
+	if !p.started {
+		p.started = true
+		p.name, p.headerErr = p.parseHeader()
+	}
+	return p.name, p.headerErr
+}
+
+// parseHeader will parse the opening line of the form:
+// 'solid name' and return the name of the solid.
+// Unless strict, the name may be empty or span several words.
+func (p *Parser) parseHeader() (string, error) {
+	tok, _ := p.scanToken()
 	if tok != lexer.SOLID {
-		return s, errors.Errorf("parse: found [%v], expected 'solid'", val)
+		return "", p.syntaxError("parse", ErrUnexpectedToken, "'solid'")
+	}
+
+	if !p.opts.strict {
+		return p.scanLine(), nil
 	}
 
-	tok, val = p.scanIgnoreWhitespace()
+	tok, name := p.scanIgnoreWhitespace()
 	if tok != lexer.WORD {
-		return s, errors.Errorf("parse: found [%v], expected name of solid", val)
+		return "", p.syntaxError("parse", ErrUnexpectedToken, "name of solid")
 	}
-	s.Name = val
 
-	tok, val = p.scanIgnoreWhitespace()
-	if tok != lexer.NEWLINE {
-		return s, errors.Errorf("parse: found [%v], expected 'newline'", val)
+	if err := p.expectNewline("parse"); err != nil {
+		return name, err
+	}
+
+	return name, nil
+}
+
+// Next will parse and return the next facet of the solid without holding on to
+// previously read facets, so the parser does not grow with the size of the file.
+// Next returns 'io.EOF' once 'endsolid' has been read. Once an error is returned
+// every following call returns the same error.
+func (p *Parser) Next() (Facet, error) {
+	if _, err := p.Name(); err != nil {
+		return Facet{}, err
+	}
+	if p.err != nil {
+		return Facet{}, p.err
 	}
 
 	for {
-		f, err := p.parseFacet()
-		if err != nil {
-			return s, errors.WithMessage(err, "parse: unable to parse facet")
+		f, err := p.nextFacet()
+		if err == nil {
+			return f, nil
+		}
+		if err == io.EOF || !p.opts.recover {
+			p.err = err
+			return f, err
 		}
-		s.Facets = append(s.Facets, f)
 
-		tok, val = p.scanIgnoreWhitespace()
-		if tok != lexer.NEWLINE {
-			return s, errors.Errorf("parse: found [%v], expected 'newline'", val)
+		// Record the error and resume at the next facet, unless there is nothing left to recover.
+		p.diagnostics = append(p.diagnostics, err)
+		if p.closed || p.b.tok == lexer.EOF {
+			p.err = io.EOF
+			return Facet{}, p.err
 		}
+		p.skipFacet()
+	}
+}
 
-		tok, val = p.scanIgnoreWhitespace()
-		if tok == lexer.ENDSOLID {
-			break
-		} else {
-			// If current token is not 'ENDSOLID' it should be 'FACET' in which case we must put it back
-			// on the buffer so the next facet can be parsed.
+// Diagnostics returns every error skipped over while recovering from malformed facets or
+// accepting a mismatched 'endsolid' name, in the order they were found. Each wraps a
+// '*SyntaxError' holding its position.
+func (p *Parser) Diagnostics() []error {
+	return p.diagnostics
+}
+
+// skipFacet will discard tokens until the start of the next facet, 'endsolid'
+// or the end of the input so parsing can resume after a malformed facet.
+func (p *Parser) skipFacet() {
+	// The token that caused the error may itself start the next facet.
+	p.unscan()
+	for {
+		tok, _ := p.scanIgnoreWhitespace()
+		switch tok {
+		case lexer.FACET, lexer.ENDSOLID, lexer.EOF:
 			p.unscan()
+			return
 		}
 	}
+}
 
-	tok, val = p.scanIgnoreWhitespace()
-	if tok != lexer.WORD {
-		return s, errors.Errorf("parse: found [%v], expected name of solid", val)
+// nextFacet will parse either a facet followed by a newline or the closing
+// 'endsolid name' in which case 'io.EOF' is returned.
+func (p *Parser) nextFacet() (Facet, error) {
+	tok, _ := p.scanToken()
+	if tok == lexer.ENDSOLID {
+		p.closed = true
+		return Facet{}, p.parseFooter()
 	}
+	// If current token is not 'ENDSOLID' it should be 'FACET' in which case we must put it back
+	// on the buffer so the facet can be parsed.
+	p.unscan()
 
-	if s.Name != val {
-		return s, errors.Errorf("parse: solid names do not match [%s] and [%s]", s.Name, val)
+	f, err := p.parseFacet()
+	if err != nil {
+		return f, errors.WithMessage(err, "parse: unable to parse facet")
 	}
 
-	return s, nil
+	if err := p.expectNewline("parse"); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// parseFooter will parse the name following 'endsolid' and return 'io.EOF'.
+// In strict mode the name must match the name of the solid. Otherwise the rest
+// of the line is accepted and a name not matching is recorded in 'Diagnostics'.
+func (p *Parser) parseFooter() error {
+	if !p.opts.strict {
+		tok, _ := p.scanIgnoreWhitespace()
+		p.unscan()
+		if tok == lexer.NEWLINE || tok == lexer.EOF {
+			return io.EOF
+		}
+
+		pos := p.b.pos
+		if name := p.scanLine(); name != p.name {
+			p.diagnostics = append(p.diagnostics, syntaxErrorAt(pos, name, "parse", ErrNameMismatch, "'"+p.name+"'"))
+		}
+		return io.EOF
+	}
+
+	tok, val := p.scanIgnoreWhitespace()
+	if tok != lexer.WORD {
+		return p.syntaxError("parse", ErrUnexpectedToken, "name of solid")
+	}
+
+	if p.name != val {
+		return p.syntaxError("parse", ErrNameMismatch, "'"+p.name+"'")
+	}
+	return io.EOF
 }
 
 // scan will read off the buffer, if buffer is currently empty then we read from the underlying scanner.
@@ -233,6 +461,7 @@ func (p *Parser) scan() (lexer.Token, string) {
 		return p.b.tok, p.b.val
 	}
 	p.b.tok, p.b.val = p.s.Scan()
+	p.b.pos = p.s.Pos()
 	return p.b.tok, p.b.val
 }
 
@@ -240,6 +469,44 @@ func (p *Parser) unscan() {
 	p.b.n = 1
 }
 
+// scanToken will return the token and value of the next token significant to the grammar.
+// Whitespace is always skipped and, unless strict, so are newlines.
+func (p *Parser) scanToken() (lexer.Token, string) {
+	tok, val := p.scanIgnoreWhitespace()
+	for !p.opts.strict && tok == lexer.NEWLINE {
+		tok, val = p.scanIgnoreWhitespace()
+	}
+	return tok, val
+}
+
+// expectNewline will return an error if the next token is not a newline in strict mode.
+// Otherwise newlines are insignificant and skipped by 'scanToken'.
+func (p *Parser) expectNewline(context string) error {
+	if !p.opts.strict {
+		return nil
+	}
+
+	tok, _ := p.scanIgnoreWhitespace()
+	if tok != lexer.NEWLINE {
+		return p.syntaxError(context, ErrUnexpectedToken, "'newline'")
+	}
+	return nil
+}
+
+// scanLine will return the raw text up to the end of the current line with
+// surrounding whitespace removed, leaving the newline to be read again.
+func (p *Parser) scanLine() string {
+	var b strings.Builder
+	for {
+		tok, val := p.scan()
+		if tok == lexer.NEWLINE || tok == lexer.EOF {
+			p.unscan()
+			return strings.TrimSpace(b.String())
+		}
+		b.WriteString(val)
+	}
+}
+
 // scanIgnoreWhitespace will return the token and value of the next non whitespace token.
 func (p *Parser) scanIgnoreWhitespace() (lexer.Token, string) {
 	tok, val := p.scan()
@@ -248,3 +515,42 @@ func (p *Parser) scanIgnoreWhitespace() (lexer.Token, string) {
 	}
 	return tok, val
 }
+
+// syntaxError will return a '*SyntaxError' of the given class for the last read
+// token wrapped with the given context, reporting what the grammar expected in its place.
+// Reaching the end of the input is always reported as 'ErrUnexpectedEOF'.
+func (p *Parser) syntaxError(context string, class error, expected string) error {
+	found := p.b.val
+	switch p.b.tok {
+	case lexer.EOF:
+		found = "EOF"
+		class = ErrUnexpectedEOF
+	case lexer.NEWLINE:
+		found = "newline"
+	}
+
+	return syntaxErrorAt(p.b.pos, found, context, class, expected)
+}
+
+// syntaxErrorAt will return a '*SyntaxError' of the given class for the value found at
+// the given position wrapped with the given context.
+func syntaxErrorAt(pos lexer.Position, found, context string, class error, expected string) error {
+	return errors.WithMessage(&SyntaxError{
+		Line:     pos.Line,
+		Col:      pos.Column,
+		Offset:   pos.Offset,
+		Expected: expected,
+		Found:    found,
+		Err:      class,
+	}, context)
+}
+
+// isKeyword will report whether the token starts or ends a statement of the grammar.
+func isKeyword(tok lexer.Token) bool {
+	switch tok {
+	case lexer.SOLID, lexer.ENDSOLID, lexer.FACET, lexer.ENDFACET, lexer.OUTER,
+		lexer.LOOP, lexer.ENDLOOP, lexer.NORMAL, lexer.VERTEX:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"errors"
+	"io"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/lenguti/STLParser/lexer"
@@ -26,6 +30,10 @@ func (m *mockScanner) Scan() (lexer.Token, string) {
 	return temp.t, temp.v
 }
 
+func (m *mockScanner) Pos() lexer.Position {
+	return lexer.Position{}
+}
+
 func TestParse(t *testing.T) {
 	// Arrange
 	var (
@@ -192,3 +200,493 @@ func TestParse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, s)
 }
+
+func TestNext(t *testing.T) {
+	// Arrange
+	var (
+		p = New(strings.NewReader(`solid foo
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1 0 0
+      vertex 1 1 0
+    endloop
+  endfacet
+  facet normal 0 1 0
+    outer loop
+      vertex 0 0 0
+      vertex 0 0 1
+      vertex 1 0 0
+    endloop
+  endfacet
+endsolid foo
+`))
+		expected = []Facet{
+			{
+				Normal:   Vector{X: 0, Y: 0, Z: 1},
+				Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 0}},
+			},
+			{
+				Normal:   Vector{X: 0, Y: 1, Z: 0},
+				Vertices: []Vector{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 0}},
+			},
+		}
+	)
+
+	// Act
+	name, err := p.Name()
+	require.NoError(t, err)
+
+	var facets []Facet
+	for {
+		f, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		facets = append(facets, f)
+	}
+
+	// Assert
+	require.Equal(t, "foo", name)
+	require.Equal(t, expected, facets)
+
+	_, err = p.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestNextErrors(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input string
+		opts  []Option
+	}{
+		"missing solid": {
+			input: "facet normal 0 0 0\n",
+		},
+		"strict mismatched names": {
+			input: "solid foo\nendsolid bar\n",
+			opts:  []Option{WithStrict()},
+		},
+		"too many vertices": {
+			input: "solid foo\nfacet normal 0 0 0\nouter loop\nvertex 0 0 0\nvertex 0 0 0\nvertex 0 0 0\nvertex 0 0 0\nendloop\nendfacet\nendsolid foo\n",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.input), tc.opts...)
+
+			// Act
+			_, err := p.Next()
+			_, again := p.Next()
+
+			// Assert
+			require.Error(t, err)
+			require.NotEqual(t, io.EOF, err)
+			require.Equal(t, err, again)
+		})
+	}
+}
+
+func TestParseNumberSyntax(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input    string
+		opts     []Option
+		expected Vector
+	}{
+		"signed and scientific": {
+			input:    "solid foo\nfacet normal -1.5 +2 .5\nouter loop\nvertex 1.234567e+02 -0 1E-3\nvertex 0 0 0\nvertex 0 0 0\nendloop\nendfacet\nendsolid foo\n",
+			expected: Vector{X: 123.4567, Y: 0, Z: 0.001},
+		},
+		"non finite": {
+			input:    "solid foo\nfacet normal nan nan nan\nouter loop\nvertex -inf inf 1\nvertex 0 0 0\nvertex 0 0 0\nendloop\nendfacet\nendsolid foo\n",
+			opts:     []Option{WithNonFinite()},
+			expected: Vector{X: math.Inf(-1), Y: math.Inf(1), Z: 1},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			s, err := New(strings.NewReader(tc.input), tc.opts...).Parse()
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, s.Facets, 1)
+			require.Equal(t, tc.expected, s.Facets[0].Vertices[0])
+		})
+	}
+}
+
+func TestParseNonFiniteDisabled(t *testing.T) {
+	// Arrange
+	p := New(strings.NewReader("solid foo\nfacet normal nan 0 0\n"))
+
+	// Act
+	_, err := p.Parse()
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestParseSyntaxErrorPosition(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input    string
+		expected SyntaxError
+	}{
+		"unexpected keyword": {
+			input:    "solid foo\n  facet norml 0 0 0\n",
+			expected: SyntaxError{Line: 2, Col: 9, Offset: 18, Expected: "'normal'", Found: "norml", Err: ErrUnexpectedToken},
+		},
+		"invalid number": {
+			input:    "solid foo\nfacet normal 0 0 0\nouter loop\n\tvertex 0 x 0\n",
+			expected: SyntaxError{Line: 4, Col: 11, Offset: 50, Expected: "'number'", Found: "x", Err: ErrUnexpectedToken},
+		},
+		"unexpected end of file": {
+			input:    "solid foo\nfacet normal 0 0 0\n",
+			expected: SyntaxError{Line: 3, Col: 1, Offset: 29, Expected: "'outer'", Found: "EOF", Err: ErrUnexpectedEOF},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := New(strings.NewReader(tc.input)).Parse()
+
+			// Assert
+			var se *SyntaxError
+			require.True(t, errors.As(err, &se), "unexpected error [%v]", err)
+			require.Equal(t, tc.expected, *se)
+		})
+	}
+}
+
+func TestParseErrorClasses(t *testing.T) {
+	// Arrange
+	facet := func(normal string, vertices ...string) string {
+		out := "facet normal " + normal + "\nouter loop\n"
+		for _, v := range vertices {
+			out += "vertex " + v + "\n"
+		}
+		return out + "endloop\nendfacet\n"
+	}
+	tcs := map[string]struct {
+		input    string
+		opts     []Option
+		expected error
+	}{
+		"unexpected token": {
+			input:    "solid foo\nfacet foo\n",
+			expected: ErrUnexpectedToken,
+		},
+		"unexpected end of file": {
+			input:    "solid foo\n" + facet("0 0 0", "0 0 0", "0 0 0", "0 0 0"),
+			expected: ErrUnexpectedEOF,
+		},
+		"invalid number": {
+			input:    "solid foo\n" + facet("0 0 1e999", "0 0 0", "0 0 0", "0 0 0") + "endsolid foo\n",
+			expected: ErrInvalidNumber,
+		},
+		"too many points": {
+			input:    "solid foo\n" + facet("0 0 0 0", "0 0 0", "0 0 0", "0 0 0") + "endsolid foo\n",
+			expected: ErrTooManyPoints,
+		},
+		"too few points": {
+			input:    "solid foo\n" + facet("0 0 0", "0 0", "0 0 0", "0 0 0") + "endsolid foo\n",
+			expected: ErrTooFewPoints,
+		},
+		"too many vertices": {
+			input:    "solid foo\n" + facet("0 0 0", "0 0 0", "0 0 0", "0 0 0", "0 0 0") + "endsolid foo\n",
+			expected: ErrTooManyVertices,
+		},
+		"too few vertices": {
+			input:    "solid foo\n" + facet("0 0 0", "0 0 0", "0 0 0") + "endsolid foo\n",
+			expected: ErrTooFewVertices,
+		},
+		"strict name mismatch": {
+			input:    "solid foo\n" + facet("0 0 0", "0 0 0", "0 0 0", "0 0 0") + "endsolid bar\n",
+			opts:     []Option{WithStrict()},
+			expected: ErrNameMismatch,
+		},
+		"strict newline": {
+			input:    "solid foo\nfacet normal 0 0 0 outer loop\n",
+			opts:     []Option{WithStrict()},
+			expected: ErrUnexpectedToken,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := New(strings.NewReader(tc.input), tc.opts...).Parse()
+
+			// Assert
+			require.True(t, errors.Is(err, tc.expected), "unexpected error [%v]", err)
+		})
+	}
+}
+
+func TestParseWithRecovery(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input       string
+		facets      int
+		diagnostics []SyntaxError
+	}{
+		"corrupted facets": {
+			input: `solid foo
+facet normal 0 0 0
+outer loop
+vertex 0 0 0
+vertex 1 x 0
+vertex 1 1 0
+endloop
+endfacet
+facet normal 0 0 1
+outer loop
+vertex 0 0 0
+vertex 1 0 0
+vertex 1 1 0
+endloop
+endfacet
+facet normal 0 0
+facet normal 0 0 1
+outer loop
+vertex 0 0 0
+vertex 1 0 0
+vertex 1 1 0
+endloop
+endfacet
+endsolid foo
+`,
+			facets: 2,
+			diagnostics: []SyntaxError{
+				{Line: 5, Col: 10, Offset: 62, Expected: "'number'", Found: "x", Err: ErrUnexpectedToken},
+				{Line: 17, Col: 1, Offset: 199, Expected: "'number'", Found: "facet", Err: ErrTooFewPoints},
+			},
+		},
+		"truncated file": {
+			input: `solid foo
+facet normal 0 0 1
+outer loop
+vertex 0 0 0
+vertex 1 0 0
+vertex 1 1 0
+endloop
+endfacet
+facet normal 0 0 1
+outer loop
+`,
+			facets: 1,
+			diagnostics: []SyntaxError{
+				{Line: 11, Col: 1, Offset: 126, Expected: "'vertex'", Found: "EOF", Err: ErrUnexpectedEOF},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.input), WithRecovery())
+
+			// Act
+			s, err := p.Parse()
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, s.Facets, tc.facets)
+			require.Len(t, p.Diagnostics(), len(tc.diagnostics))
+			for i, d := range p.Diagnostics() {
+				var se *SyntaxError
+				require.True(t, errors.As(d, &se))
+				require.Equal(t, tc.diagnostics[i], *se)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	// Arrange
+	var (
+		solid = func(name string) string {
+			return "solid " + name + "\nfacet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 1 1 0\nendloop\nendfacet\nendsolid " + name + "\n"
+		}
+		p = New(strings.NewReader(solid("foo") + "\n" + solid("bar") + solid("baz")))
+	)
+
+	// Act
+	m, err := p.ParseAll()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, m.Solids, 3)
+	for i, name := range []string{"foo", "bar", "baz"} {
+		require.Equal(t, name, m.Solids[i].Name)
+		require.Len(t, m.Solids[i].Facets, 1)
+	}
+}
+
+func TestMore(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		input    string
+		opts     []Option
+		expected bool
+		err      error
+	}{
+		"single solid": {
+			input:    "solid foo\nendsolid foo\n\n",
+			expected: false,
+		},
+		"unread facets": {
+			input:    "solid foo\nfacet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 1 1 0\nendloop\nendfacet\nendsolid foo\nsolid bar\nendsolid bar\n",
+			expected: true,
+		},
+		"trailing garbage": {
+			input:    "solid foo\nendsolid foo\nfoo\n",
+			expected: false,
+		},
+		"strict trailing garbage": {
+			input:    "solid foo\nendsolid foo\nfoo\n",
+			opts:     []Option{WithStrict()},
+			expected: false,
+			err:      ErrUnexpectedToken,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.input), tc.opts...)
+			_, err := p.Name()
+			require.NoError(t, err)
+
+			// Act
+			more, err := p.More()
+
+			// Assert
+			require.Equal(t, tc.expected, more)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), "unexpected error [%v]", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMoreBadHeader(t *testing.T) {
+	// Arrange
+	p := New(strings.NewReader("foo bar\n"))
+
+	// Act
+	more, err := p.More()
+
+	// Assert
+	require.False(t, more)
+	require.True(t, errors.Is(err, ErrUnexpectedToken), "unexpected error [%v]", err)
+}
+
+func TestParseGrammarVariations(t *testing.T) {
+	// Arrange
+	const body = "facet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 1 1 0\nendloop\nendfacet\n"
+	tcs := map[string]struct {
+		input    string
+		expected string
+	}{
+		"empty name": {
+			input:    "solid\n" + body + "endsolid\n",
+			expected: "",
+		},
+		"multi word name with digits and underscores": {
+			input:    "solid  my_part v2 (copy)\n" + body + "endsolid my_part v2 (copy)\n",
+			expected: "my_part v2 (copy)",
+		},
+		"crlf line endings": {
+			input:    strings.Replace("solid foo\n"+body+"endsolid foo\n", "\n", "\r\n", -1),
+			expected: "foo",
+		},
+		"blank lines and split statements": {
+			input:    "\n\nsolid foo\n\n  facet\n normal 0 0 1\n\n outer loop vertex 0 0 0 vertex 1 0 0\n\tvertex 1\n1 0\nendloop endfacet\n\nendsolid foo\n",
+			expected: "foo",
+		},
+		"missing endsolid name": {
+			input:    "solid foo\n" + body + "endsolid",
+			expected: "foo",
+		},
+		"trailing garbage": {
+			input:    "solid foo\n" + body + "endsolid foo\n\x00\x00 exported by tool\n",
+			expected: "foo",
+		},
+		"trailing words after endsolid name": {
+			input:    "solid foo\n" + body + "endsolid foo extra\n",
+			expected: "foo",
+		},
+		"endsolid name without solid name": {
+			input:    "solid\n" + body + "endsolid foo\n",
+			expected: "",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			m, err := New(strings.NewReader(tc.input)).ParseAll()
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, m.Solids, 1)
+			require.Equal(t, tc.expected, m.Solids[0].Name)
+			require.Len(t, m.Solids[0].Facets, 1)
+			require.Equal(t, Vector{X: 1, Y: 1, Z: 0}, m.Solids[0].Facets[0].Vertices[2])
+		})
+	}
+}
+
+func TestParseNameMismatchDiagnostic(t *testing.T) {
+	// Arrange
+	var (
+		p        = New(strings.NewReader("solid my part\nendsolid my other part\n"))
+		expected = SyntaxError{Line: 2, Col: 10, Offset: 23, Expected: "'my part'", Found: "my other part", Err: ErrNameMismatch}
+	)
+
+	// Act
+	s, err := p.Parse()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "my part", s.Name)
+	require.Len(t, p.Diagnostics(), 1)
+	var se *SyntaxError
+	require.True(t, errors.As(p.Diagnostics()[0], &se))
+	require.Equal(t, expected, *se)
+}
+
+func TestParseStrict(t *testing.T) {
+	// Arrange
+	const body = "facet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 1 1 0\nendloop\nendfacet\n"
+	tcs := map[string]struct {
+		input string
+	}{
+		"empty name": {
+			input: "solid\n" + body + "endsolid\n",
+		},
+		"blank lines": {
+			input: "solid foo\n\n" + body + "endsolid foo\n",
+		},
+		"missing endsolid name": {
+			input: "solid foo\n" + body + "endsolid",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := New(strings.NewReader(tc.input), WithStrict()).ParseAll()
+
+			// Assert
+			require.True(t, errors.Is(err, ErrUnexpectedToken) || errors.Is(err, ErrUnexpectedEOF), "unexpected error [%v]", err)
+		})
+	}
+}
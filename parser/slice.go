@@ -0,0 +1,158 @@
+package parser
+
+// Plane represents an infinite plane through a point, facing the direction of its normal.
+type Plane struct {
+	Point  Vector
+	Normal Vector // Does not need to be of unit length.
+}
+
+// HorizontalPlane returns the plane at height z facing up the Z axis.
+func HorizontalPlane(z float64) Plane {
+	return Plane{Point: Vector{Z: z}, Normal: Vector{Z: 1}}
+}
+
+// Contour represents a polyline where a solid crosses a plane.
+type Contour struct {
+	Points []Vector // Points on the plane, the last one joining the first when closed.
+	Closed bool     // Whether the polyline forms a loop, which requires a closed mesh.
+	Area   float64  // Area enclosed by a closed loop, negative when it winds clockwise.
+	Outer  bool     // Whether the loop bounds material from the outside rather than a cavity.
+}
+
+// segment represents the line where a facet crosses a plane, running with the
+// material on its left when looking down the normal of the plane.
+type segment struct {
+	a, b Vector
+}
+
+// Slice will intersect the solid with the plane and return the contours of the cross section,
+// open chains first and then loops. Outer loops need facets wound outward, see 'Solid.Orient'.
+func (s Solid) Slice(p Plane) []Contour {
+	l := p.Normal.length()
+	if l == 0 {
+		return nil
+	}
+	n := p.Normal.scale(1 / l)
+
+	var segments []segment
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			continue
+		}
+
+		// Vertices lying on the plane count as above it, so facets touching the plane or
+		// lying in it add no segment.
+		var (
+			points []Vector
+			d      [3]float64
+		)
+		for j, v := range f.Vertices {
+			d[j] = v.sub(p.Point).dot(n)
+		}
+		for j := 0; j < 3; j++ {
+			k := (j + 1) % 3
+			if (d[j] >= 0) != (d[k] >= 0) {
+				points = append(points, intersect(f.Vertices[j], f.Vertices[k], d[j], d[k]))
+			}
+		}
+		if len(points) != 2 || points[0] == points[1] {
+			continue
+		}
+
+		// Run each segment with the material on its left, so loops wind counter clockwise
+		// around material and clockwise around cavities and the sign of their area tells
+		// outer and inner loops apart.
+		sg := segment{a: points[0], b: points[1]}
+		if sg.b.sub(sg.a).dot(n.cross(f.crossProduct())) < 0 {
+			sg.a, sg.b = sg.b, sg.a
+		}
+		segments = append(segments, sg)
+	}
+
+	return chainSegments(segments, n)
+}
+
+// intersect will return the point where the edge from a to b crosses the plane, given the
+// signed distances da and db of its ends. The ends are ordered first so facets sharing the
+// edge get the exact same point, whichever way they run it.
+func intersect(a, b Vector, da, db float64) Vector {
+	if less(b, a) {
+		a, b, da, db = b, a, db, da
+	}
+	switch {
+	case da == 0:
+		return a
+	case db == 0:
+		return b
+	}
+	return a.add(b.sub(a).scale(da / (da - db)))
+}
+
+// chainSegments will join segments sharing an end into contours. Chains with a loose end are
+// walked from that end first, so each open chain comes out whole before the loops are traced.
+func chainSegments(segments []segment, n Vector) []Contour {
+	var (
+		outgoing = map[Vector][]int{}
+		incoming = map[Vector]int{}
+	)
+	for i, sg := range segments {
+		outgoing[sg.a] = append(outgoing[sg.a], i)
+		incoming[sg.b]++
+	}
+
+	var (
+		contours []Contour
+		used     = make([]bool, len(segments))
+		trace    = func(i int) {
+			var (
+				start = segments[i].a
+				c     = Contour{Points: []Vector{start}}
+			)
+			for i >= 0 {
+				used[i] = true
+				next := segments[i].b
+				if next == start {
+					c.Closed = true
+					break
+				}
+				c.Points = append(c.Points, next)
+
+				i = -1
+				for _, k := range outgoing[next] {
+					if !used[k] {
+						i = k
+						break
+					}
+				}
+			}
+
+			if c.Closed {
+				c.Area = polygonArea(c.Points, n)
+				c.Outer = c.Area > 0
+			}
+			contours = append(contours, c)
+		}
+	)
+	for i, sg := range segments {
+		if !used[i] && incoming[sg.a] == 0 {
+			trace(i)
+		}
+	}
+	for i := range segments {
+		if !used[i] {
+			trace(i)
+		}
+	}
+	return contours
+}
+
+// polygonArea will return the area of the polygon lying in the plane with the unit normal
+// n, positive when it winds counter clockwise looking down the normal.
+func polygonArea(polygon []Vector, n Vector) float64 {
+	var sum Vector
+	for i := range polygon {
+		sum = sum.add(polygon[i].cross(polygon[(i+1)%len(polygon)]))
+	}
+	return sum.dot(n) / 2
+}
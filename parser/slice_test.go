@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlice(t *testing.T) {
+	// Arrange
+	var (
+		cube   = unitCube()
+		hollow = Merge(MergeOptions{}, cube, flipped(unitCube().Scale(Vector{X: 0.5, Y: 0.5, Z: 0.5}).Translate(Vector{X: 0.25, Y: 0.25, Z: 0.25})))
+	)
+	tcs := map[string]struct {
+		solid    Solid
+		plane    Plane
+		expected []Contour
+	}{
+		"middle": {
+			solid: cube,
+			plane: HorizontalPlane(0.5),
+			expected: []Contour{
+				{Closed: true, Area: 1, Outer: true},
+			},
+		},
+		"top": {
+			solid: cube,
+			plane: HorizontalPlane(1),
+			expected: []Contour{
+				{Closed: true, Area: 1, Outer: true},
+			},
+		},
+		"bottom": {
+			solid:    cube,
+			plane:    HorizontalPlane(0),
+			expected: nil,
+		},
+		"above": {
+			solid:    cube,
+			plane:    HorizontalPlane(2),
+			expected: nil,
+		},
+		"inward": {
+			solid: flipped(cube),
+			plane: HorizontalPlane(0.5),
+			expected: []Contour{
+				{Closed: true, Area: -1},
+			},
+		},
+		"diagonal": {
+			solid: cube,
+			plane: Plane{Point: Vector{X: 1}, Normal: Vector{X: 1, Y: 1}},
+			expected: []Contour{
+				{Closed: true, Area: math.Sqrt2, Outer: true},
+			},
+		},
+		"looking down": {
+			solid: cube,
+			plane: Plane{Point: Vector{Z: 0.5}, Normal: Vector{Z: -3}},
+			expected: []Contour{
+				{Closed: true, Area: 1, Outer: true},
+			},
+		},
+		"cavity": {
+			solid: hollow,
+			plane: HorizontalPlane(0.5),
+			expected: []Contour{
+				{Closed: true, Area: 1, Outer: true},
+				{Closed: true, Area: -0.25},
+			},
+		},
+		"open": {
+			solid: without(cube, 4, 5),
+			plane: HorizontalPlane(0.5),
+			expected: []Contour{
+				{},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := tc.solid.Slice(tc.plane)
+
+			// Assert
+			require.Len(t, out, len(tc.expected))
+			for i, e := range tc.expected {
+				require.Equal(t, e.Closed, out[i].Closed)
+				require.Equal(t, e.Outer, out[i].Outer)
+				require.InDelta(t, e.Area, out[i].Area, 1e-12)
+				for _, p := range out[i].Points {
+					require.InDelta(t, 0, p.sub(tc.plane.Point).dot(tc.plane.Normal), 1e-12)
+				}
+			}
+		})
+	}
+}
+
+func TestSliceChain(t *testing.T) {
+	// Act
+	out := unitCube().Slice(HorizontalPlane(0.25))
+
+	// Assert
+	require.Len(t, out, 1)
+	require.Equal(t, []Vector{
+		{X: 0.25, Y: 0, Z: 0.25},
+		{X: 1, Y: 0, Z: 0.25},
+		{X: 1, Y: 0.25, Z: 0.25},
+		{X: 1, Y: 1, Z: 0.25},
+		{X: 0.25, Y: 1, Z: 0.25},
+		{X: 0, Y: 1, Z: 0.25},
+		{X: 0, Y: 0.25, Z: 0.25},
+		{X: 0, Y: 0, Z: 0.25},
+	}, out[0].Points)
+}
+
+func TestSliceZeroNormal(t *testing.T) {
+	// Assert
+	require.Nil(t, unitCube().Slice(Plane{}))
+}
@@ -0,0 +1,58 @@
+package parser
+
+import "fmt"
+
+// Split will partition the solid into the groups of facets sharing a vertex and return one
+// solid per group in order of their first facet, named as in 'plate_1' when there are several.
+func (s Solid) Split() []Solid {
+	var (
+		sets  = newUnionFind(len(s.Facets))
+		first = map[Vector]int{}
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		if len(s.Facets[i].Vertices) != 3 {
+			continue
+		}
+		for _, v := range s.Facets[i].Vertices {
+			j, ok := first[v]
+			if !ok {
+				first[v] = i
+				continue
+			}
+			sets.union(j, i)
+		}
+	}
+
+	var (
+		parts []Solid
+		index = map[int]int{}
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		if len(s.Facets[i].Vertices) != 3 {
+			continue
+		}
+		root := sets.find(i)
+		p, ok := index[root]
+		if !ok {
+			p = len(parts)
+			index[root] = p
+			parts = append(parts, Solid{})
+		}
+		parts[p].Facets = append(parts[p].Facets, s.Facets[i])
+	}
+
+	if len(parts) == 1 {
+		parts[0].Name, parts[0].Header = s.Name, s.Header
+		return parts
+	}
+
+	// Headers are dropped so they are derived from the new names when written.
+	name := s.Name
+	if name == "" {
+		name = "solid"
+	}
+	for i := range parts {
+		parts[i].Name = fmt.Sprintf("%s_%d", name, i+1)
+	}
+	return parts
+}
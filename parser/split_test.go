@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	// Arrange
+	var (
+		cube  = unitCube()
+		moved = unitCube().Translate(Vector{X: 5})
+		a     = Vector{X: 0, Y: 0, Z: 0}
+		b     = Vector{X: 1, Y: 0, Z: 0}
+		c     = Vector{X: 0, Y: 1, Z: 0}
+		d     = Vector{X: -1, Y: 0, Z: 0}
+		e     = Vector{X: 0, Y: -1, Z: 0}
+	)
+	tcs := map[string]struct {
+		solid    Solid
+		expected []Solid
+	}{
+		"single": {
+			solid:    Solid{Name: "cube", Header: []byte("cube"), Facets: cube.Facets},
+			expected: []Solid{{Name: "cube", Header: []byte("cube"), Facets: cube.Facets}},
+		},
+		"interleaved shells": {
+			solid: Solid{
+				Name:   "plate",
+				Header: []byte("plate"),
+				Facets: []Facet{cube.Facets[0], moved.Facets[0], cube.Facets[1], moved.Facets[1]},
+			},
+			expected: []Solid{
+				{Name: "plate_1", Facets: []Facet{cube.Facets[0], cube.Facets[1]}},
+				{Name: "plate_2", Facets: []Facet{moved.Facets[0], moved.Facets[1]}},
+			},
+		},
+		"shared vertex": {
+			solid: Solid{Facets: []Facet{
+				{Vertices: []Vector{a, b, c}},
+				{Vertices: []Vector{a, d, e}},
+			}},
+			expected: []Solid{
+				{Facets: []Facet{{Vertices: []Vector{a, b, c}}, {Vertices: []Vector{a, d, e}}}},
+			},
+		},
+		"unnamed": {
+			solid: Solid{Facets: []Facet{
+				{Vertices: []Vector{b, c, {X: 1, Y: 1}}},
+				{Vertices: []Vector{a, b}},
+				{Vertices: []Vector{d, e, {X: -1, Y: -1}}},
+			}},
+			expected: []Solid{
+				{Name: "solid_1", Facets: []Facet{{Vertices: []Vector{b, c, {X: 1, Y: 1}}}}},
+				{Name: "solid_2", Facets: []Facet{{Vertices: []Vector{d, e, {X: -1, Y: -1}}}}},
+			},
+		},
+		"empty": {
+			solid:    Solid{Name: "empty"},
+			expected: nil,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := tc.solid.Split()
+
+			// Assert
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
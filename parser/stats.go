@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// Stats represents statistics of a solid accumulated one facet at a time,
+// allowing a file to be summarized while it is streamed with 'Decoder.Next'.
+type Stats struct {
+	Triangles   int     // Number of facets added.
+	SurfaceArea float64 // Sum of the area of every facet.
+	Volume      float64 // Signed volume, only meaningful when 'Closed' reports true.
+	Min, Max    Vector  // Bounding box of every vertex added.
+	Duplicates  int     // Number of facets whose vertices were already seen.
+
+	// Only a 64 bit hash of each facet is kept so memory grows far slower than
+	// holding on to the facets themselves.
+	seen map[uint64]struct{}
+
+	// Sum of the hash of every directed edge minus the hash of its reverse. Every
+	// edge of a closed, consistently oriented mesh is cancelled out by its neighbor.
+	edgeSum uint64
+}
+
+// NewStats returns a pointer to an empty 'Stats'.
+func NewStats() *Stats {
+	return &Stats{
+		Min:  Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)},
+		Max:  Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)},
+		seen: map[uint64]struct{}{},
+	}
+}
+
+// Add will update the statistics with the given facet.
+func (st *Stats) Add(f Facet) {
+	st.Triangles++
+	st.SurfaceArea += f.Area()
+	st.Volume += f.signedVolume()
+
+	for i := 0; i < len(f.Vertices); i++ {
+		v := f.Vertices[i]
+		st.Min = Vector{X: min(st.Min.X, v.X), Y: min(st.Min.Y, v.Y), Z: min(st.Min.Z, v.Z)}
+		st.Max = Vector{X: max(st.Max.X, v.X), Y: max(st.Max.Y, v.Y), Z: max(st.Max.Z, v.Z)}
+	}
+
+	if len(f.Vertices) == 3 {
+		for _, e := range facetEdges(f) {
+			st.edgeSum += hashEdge(e) - hashEdge(e.reverse())
+		}
+	}
+
+	// Facets are compared the same way as 'Solid.CheckDuplicates'.
+	key := hashVectors(f.Vertices...)
+	if _, ok := st.seen[key]; ok {
+		st.Duplicates++
+	} else {
+		st.seen[key] = struct{}{}
+	}
+}
+
+// Merge will add the statistics of o into st. Duplicates are only
+// counted within each accumulator, not across them.
+func (st *Stats) Merge(o *Stats) {
+	st.Triangles += o.Triangles
+	st.SurfaceArea += o.SurfaceArea
+	st.Volume += o.Volume
+	st.Min = Vector{X: min(st.Min.X, o.Min.X), Y: min(st.Min.Y, o.Min.Y), Z: min(st.Min.Z, o.Min.Z)}
+	st.Max = Vector{X: max(st.Max.X, o.Max.X), Y: max(st.Max.Y, o.Max.Y), Z: max(st.Max.Z, o.Max.Z)}
+	st.Duplicates += o.Duplicates
+	st.edgeSum += o.edgeSum
+}
+
+// Closed will report whether every directed edge added was cancelled out by an edge
+// running the opposite way, which holds for a closed mesh with a consistent orientation
+// and tells whether 'Volume' can be trusted. Unlike 'Solid.Volume' this takes constant
+// memory, at the cost of not noticing edges shared by more than two facets.
+func (st *Stats) Closed() bool {
+	return st.Triangles > 0 && st.edgeSum == 0
+}
+
+// hashEdge will return a 64 bit hash of the directed edge.
+func hashEdge(e edge) uint64 {
+	return hashVectors(e.a, e.b)
+}
+
+// hashVectors will return a hash of the exact coordinates of the vectors, in order.
+func hashVectors(vs ...Vector) uint64 {
+	var (
+		h   = fnv.New64a()
+		buf = make([]byte, 8)
+	)
+	for _, v := range vs {
+		for _, f := range []float64{v.X, v.Y, v.Z} {
+			// Negative zero must hash the same as zero, as they compare equal.
+			if f == 0 {
+				f = 0
+			}
+			binary.LittleEndian.PutUint64(buf, math.Float64bits(f))
+			_, _ = h.Write(buf)
+		}
+	}
+	return h.Sum64()
+}
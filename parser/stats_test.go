@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	// Arrange
+	var (
+		s  = unitCube()
+		st = NewStats()
+	)
+	s.Facets = append(s.Facets, s.Facets[0])
+
+	// Act
+	for _, f := range s.Facets {
+		st.Add(f)
+	}
+
+	// Assert
+	min, max := s.BoundingBox()
+	require.Equal(t, 13, st.Triangles)
+	require.InDelta(t, s.SurfaceArea(), st.SurfaceArea, 1e-12)
+	require.InDelta(t, 6.5, st.SurfaceArea, 1e-12)
+	require.Equal(t, min, st.Min)
+	require.Equal(t, max, st.Max)
+	require.Equal(t, 1, st.Duplicates)
+}
+
+func TestStatsDuplicatesNegativeZero(t *testing.T) {
+	// Arrange
+	var (
+		st = NewStats()
+		s  = Solid{
+			Facets: []Facet{
+				{Vertices: []Vector{{X: 0, Y: 1, Z: 2}, {X: 3, Y: 4, Z: 5}, {X: 6, Y: 7, Z: 8}}},
+				{Vertices: []Vector{{X: math.Copysign(0, -1), Y: 1, Z: 2}, {X: 3, Y: 4, Z: 5}, {X: 6, Y: 7, Z: 8}}},
+			},
+		}
+	)
+
+	// Act
+	for _, f := range s.Facets {
+		st.Add(f)
+	}
+
+	// Assert
+	require.True(t, s.CheckDuplicates())
+	require.Equal(t, 1, st.Duplicates)
+}
+
+func TestStatsVolume(t *testing.T) {
+	// Arrange
+	st := NewStats()
+
+	// Act
+	for _, f := range unitCube().Facets {
+		st.Add(f)
+	}
+
+	// Assert
+	require.InDelta(t, 1.0, st.Volume, 1e-12)
+	require.Equal(t, 0, st.Duplicates)
+}
+
+func TestStatsMerge(t *testing.T) {
+	// Arrange
+	var (
+		cube   = unitCube()
+		first  = NewStats()
+		second = NewStats()
+		total  = NewStats()
+	)
+	for i, f := range cube.Facets {
+		if i < 6 {
+			first.Add(f)
+		} else {
+			second.Add(f)
+		}
+		total.Add(f)
+	}
+
+	// Act
+	first.Merge(second)
+
+	// Assert
+	require.Equal(t, total.Triangles, first.Triangles)
+	require.InDelta(t, total.SurfaceArea, first.SurfaceArea, 1e-12)
+	require.InDelta(t, total.Volume, first.Volume, 1e-12)
+	require.Equal(t, total.Min, first.Min)
+	require.Equal(t, total.Max, first.Max)
+}
+
+func TestStatsClosed(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		solid    Solid
+		expected bool
+	}{
+		"closed cube": {
+			solid:    unitCube(),
+			expected: true,
+		},
+		"open cube": {
+			solid:    Solid{Facets: unitCube().Facets[1:]},
+			expected: false,
+		},
+		"empty": {
+			solid:    Solid{},
+			expected: false,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			st := NewStats()
+
+			// Act
+			for _, f := range tc.solid.Facets {
+				st.Add(f)
+			}
+
+			// Assert
+			require.Equal(t, tc.expected, st.Closed())
+		})
+	}
+}
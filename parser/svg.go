@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// SVGEncoder represents our object for writing layers of a solid as an SVG document.
+type SVGEncoder struct {
+	w         *bufio.Writer
+	precision int
+	bounds    bool
+	lo, hi    Vector
+}
+
+// NewSVGEncoder returns a pointer to an 'SVGEncoder' writing coordinates with the
+// given number of digits after the decimal point, -1 writing the fewest digits needed.
+func NewSVGEncoder(w io.Writer, precision int) *SVGEncoder {
+	return &SVGEncoder{
+		w:         bufio.NewWriter(w),
+		precision: precision,
+	}
+}
+
+// SetBounds will fix the area drawn for each layer to the X and Y range of the min and max
+// vertices instead of fitting the contours being encoded.
+func (e *SVGEncoder) SetBounds(lo, hi Vector) {
+	e.bounds = true
+	e.lo, e.hi = lo, hi
+}
+
+// Encode will write the layers as a single SVG document looking down the Z axis, laid out
+// side by side in a grid in reading order with a group per layer holding its Z value.
+func (e *SVGEncoder) Encode(layers ...Layer) error {
+	lo, hi := e.lo, e.hi
+	if !e.bounds {
+		lo = Vector{X: math.Inf(1), Y: math.Inf(1)}
+		hi = Vector{X: math.Inf(-1), Y: math.Inf(-1)}
+		for _, l := range layers {
+			for _, c := range l.Contours {
+				for _, p := range c.Points {
+					lo = Vector{X: min(lo.X, p.X), Y: min(lo.Y, p.Y)}
+					hi = Vector{X: max(hi.X, p.X), Y: max(hi.Y, p.Y)}
+				}
+			}
+		}
+	}
+	if !(lo.X <= hi.X && lo.Y <= hi.Y) {
+		lo, hi = Vector{}, Vector{}
+	}
+
+	// Cells are spaced by a tenth of their largest side and filled row by row.
+	var (
+		width, height = hi.X - lo.X, hi.Y - lo.Y
+		gap           = max(width, height) / 10
+		cols          = int(math.Max(1, math.Ceil(math.Sqrt(float64(len(layers))))))
+		rows          = int(math.Max(1, math.Ceil(float64(len(layers))/float64(cols))))
+	)
+
+	// SVG coordinates grow downward, so Y is negated to keep the usual view from above.
+	_, _ = e.w.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="`)
+	_, _ = e.w.WriteString(e.formatPoint(lo.X, -hi.Y) + " ")
+	_, _ = e.w.WriteString(e.formatPoint(float64(cols)*(width+gap)-gap, float64(rows)*(height+gap)-gap))
+	_, _ = e.w.WriteString("\">\n")
+	for i, l := range layers {
+		var (
+			x = float64(i%cols) * (width + gap)
+			y = float64(i/cols) * (height + gap)
+		)
+		_, _ = e.w.WriteString(`  <g id="layer-` + strconv.Itoa(i+1) + `" data-z="` + e.formatFloat(l.Z) + `" transform="translate(` + e.formatPoint(x, y) + ")\">\n")
+		_, _ = e.w.WriteString("    <title>z " + e.formatFloat(l.Z) + "</title>\n")
+
+		// Loops are filled as one path with the even-odd rule so cavities show as holes.
+		var fill, stroke []byte
+		for _, c := range l.Contours {
+			if c.Closed {
+				fill = e.appendPath(fill, c)
+			} else {
+				stroke = e.appendPath(stroke, c)
+			}
+		}
+		if len(fill) > 0 {
+			_, _ = e.w.WriteString(`    <path fill="black" fill-rule="evenodd" d="`)
+			_, _ = e.w.Write(fill)
+			_, _ = e.w.WriteString("\"/>\n")
+		}
+		if len(stroke) > 0 {
+			_, _ = e.w.WriteString(`    <path fill="none" stroke="red" vector-effect="non-scaling-stroke" d="`)
+			_, _ = e.w.Write(stroke)
+			_, _ = e.w.WriteString("\"/>\n")
+		}
+
+		_, _ = e.w.WriteString("  </g>\n")
+	}
+	_, _ = e.w.WriteString("</svg>\n")
+
+	if err := e.w.Flush(); err != nil {
+		return errors.WithMessage(err, "encode svg: unable to write layers")
+	}
+	return nil
+}
+
+// appendPath will append the contour as path data, closing it when the contour is closed.
+func (e *SVGEncoder) appendPath(b []byte, c Contour) []byte {
+	for i, p := range c.Points {
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		if i == 0 {
+			b = append(b, 'M')
+		} else {
+			b = append(b, 'L')
+		}
+		b = append(b, e.formatPoint(p.X, -p.Y)...)
+	}
+	if c.Closed {
+		b = append(b, " Z"...)
+	}
+	return b
+}
+
+// formatPoint will format a point as its space separated X and Y values.
+func (e *SVGEncoder) formatPoint(x, y float64) string {
+	return e.formatFloat(x) + " " + e.formatFloat(y)
+}
+
+// formatFloat will format f with the precision of the encoder, writing negative zero as '0'.
+func (e *SVGEncoder) formatFloat(f float64) string {
+	if f == 0 {
+		f = 0
+	}
+	return strconv.FormatFloat(f, 'f', e.precision, 64)
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVGEncode(t *testing.T) {
+	// Arrange
+	var (
+		buf    = new(bytes.Buffer)
+		e      = NewSVGEncoder(buf, -1)
+		layers = []Layer{
+			{
+				Z: 0.5,
+				Contours: []Contour{
+					{Points: []Vector{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}, Closed: true, Area: 4, Outer: true},
+					{Points: []Vector{{X: 0.5, Y: 0.5}, {X: 0.5, Y: 1.5}, {X: 1.5, Y: 1.5}}, Closed: true, Area: -0.5},
+				},
+			},
+			{
+				Z: 1.5,
+				Contours: []Contour{
+					{Points: []Vector{{X: -1, Y: 0}, {X: 0, Y: 3}}},
+				},
+			},
+			{
+				Z: 2.5,
+			},
+		}
+		expected = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-1 -3 6.3 6.3">
+  <g id="layer-1" data-z="0.5" transform="translate(0 0)">
+    <title>z 0.5</title>
+    <path fill="black" fill-rule="evenodd" d="M0 0 L2 0 L2 -2 L0 -2 Z M0.5 -0.5 L0.5 -1.5 L1.5 -1.5 Z"/>
+  </g>
+  <g id="layer-2" data-z="1.5" transform="translate(3.3 0)">
+    <title>z 1.5</title>
+    <path fill="none" stroke="red" vector-effect="non-scaling-stroke" d="M-1 0 L0 -3"/>
+  </g>
+  <g id="layer-3" data-z="2.5" transform="translate(0 3.3)">
+    <title>z 2.5</title>
+  </g>
+</svg>
+`
+	)
+
+	// Act
+	err := e.Encode(layers...)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestSVGEncodeBounds(t *testing.T) {
+	// Arrange
+	var (
+		buf   = new(bytes.Buffer)
+		e     = NewSVGEncoder(buf, -1)
+		layer = Layer{
+			Z: 0.5,
+			Contours: []Contour{
+				{Points: []Vector{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}}, Closed: true, Area: 0.5, Outer: true},
+			},
+		}
+		expected = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-2 -4 6 5">
+  <g id="layer-1" data-z="0.5" transform="translate(0 0)">
+    <title>z 0.5</title>
+    <path fill="black" fill-rule="evenodd" d="M1 -1 L2 -1 L2 -2 Z"/>
+  </g>
+</svg>
+`
+	)
+	e.SetBounds(Vector{X: -2, Y: -1, Z: -9}, Vector{X: 4, Y: 4, Z: 9})
+
+	// Act
+	err := e.Encode(layer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestSVGEncodeEmpty(t *testing.T) {
+	// Arrange
+	buf := new(bytes.Buffer)
+
+	// Act
+	err := NewSVGEncoder(buf, 2).Encode()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0.00 0.00 0.00 0.00\">\n</svg>\n", buf.String())
+}
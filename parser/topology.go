@@ -0,0 +1,209 @@
+package parser
+
+// edge represents a directed edge running from vertex a to vertex b of a facet.
+type edge struct {
+	a, b Vector
+}
+
+// reverse returns the edge running the opposite way.
+func (e edge) reverse() edge {
+	return edge{a: e.b, b: e.a}
+}
+
+// facetEdges returns the three directed edges of a facet following its winding.
+func facetEdges(f Facet) [3]edge {
+	vs := f.Vertices
+	return [3]edge{
+		{a: vs[0], b: vs[1]},
+		{a: vs[1], b: vs[2]},
+		{a: vs[2], b: vs[0]},
+	}
+}
+
+// orientedClosed will report whether every directed edge of the facets is matched by
+// exactly one edge running the opposite way, meaning the facets form a closed mesh
+// with a consistent orientation.
+func orientedClosed(facets []Facet) bool {
+	if len(facets) == 0 {
+		return false
+	}
+
+	counts := map[edge]int{}
+	for i := 0; i < len(facets); i++ {
+		if len(facets[i].Vertices) != 3 {
+			return false
+		}
+		for _, e := range facetEdges(facets[i]) {
+			counts[e]++
+		}
+	}
+
+	for e, n := range counts {
+		if n != 1 || counts[e.reverse()] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Edge represents an undirected edge of a solid along with the facets sharing it.
+type Edge struct {
+	A, B   Vector
+	Facets []int // Indices into 'Solid.Facets' of every facet using the edge.
+}
+
+// EdgeReport represents the edge topology of a solid.
+type EdgeReport struct {
+	Edges       int    // Number of distinct edges.
+	Boundary    []Edge // Open edges used by a single facet.
+	NonManifold []Edge // Edges shared by more than two facets.
+	Holes       int    // Number of holes, each a connected group of boundary edges closing into loops.
+}
+
+// Watertight will report whether every edge is shared by exactly two facets.
+func (r EdgeReport) Watertight() bool {
+	return r.Edges > 0 && len(r.Boundary) == 0 && len(r.NonManifold) == 0
+}
+
+// AnalyzeEdges will build the adjacency between edges and facets of the solid and
+// report its open and non-manifold edges along with the number of holes. Vertices
+// are matched by exact coordinates and facets without 3 vertices are ignored.
+func (s Solid) AnalyzeEdges() EdgeReport {
+	var (
+		edges []Edge
+		index = map[edge]int{}
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		if len(s.Facets[i].Vertices) != 3 {
+			continue
+		}
+		for _, e := range facetEdges(s.Facets[i]) {
+			// Collapsed edges of degenerate facets do not connect anything.
+			if e.a == e.b {
+				continue
+			}
+
+			key := e.undirected()
+			j, ok := index[key]
+			if !ok {
+				j = len(edges)
+				index[key] = j
+				edges = append(edges, Edge{A: key.a, B: key.b})
+			}
+			edges[j].Facets = append(edges[j].Facets, i)
+		}
+	}
+
+	r := EdgeReport{Edges: len(edges)}
+	for _, e := range edges {
+		switch {
+		case len(e.Facets) == 1:
+			r.Boundary = append(r.Boundary, e)
+		case len(e.Facets) > 2:
+			r.NonManifold = append(r.NonManifold, e)
+		}
+	}
+	r.Holes = countHoles(r.Boundary)
+
+	return r
+}
+
+// countHoles will return the number of connected groups formed by the given edges that close
+// into loops, meaning every vertex of the group has an even number of edges. Open chains, such
+// as the free edges of a fin, are not holes.
+func countHoles(edges []Edge) int {
+	var (
+		vertices = map[Vector]int{}
+		degrees  []int
+	)
+	vertexIndex := func(v Vector) int {
+		i, ok := vertices[v]
+		if !ok {
+			i = len(vertices)
+			vertices[v] = i
+			degrees = append(degrees, 0)
+		}
+		degrees[i]++
+		return i
+	}
+
+	pairs := make([][2]int, len(edges))
+	for i, e := range edges {
+		pairs[i] = [2]int{vertexIndex(e.A), vertexIndex(e.B)}
+	}
+
+	sets := newUnionFind(len(vertices))
+	for _, p := range pairs {
+		sets.union(p[0], p[1])
+	}
+
+	open := map[int]bool{}
+	for i, d := range degrees {
+		root := sets.find(i)
+		if _, ok := open[root]; !ok {
+			open[root] = false
+		}
+		if d%2 != 0 {
+			open[root] = true
+		}
+	}
+
+	var holes int
+	for _, o := range open {
+		if !o {
+			holes++
+		}
+	}
+	return holes
+}
+
+// undirected returns the edge with its vertices in a canonical order so
+// both directions of an edge produce the same key.
+func (e edge) undirected() edge {
+	if less(e.b, e.a) {
+		return e.reverse()
+	}
+	return e
+}
+
+// less will report whether a sorts before b, comparing X, then Y, then Z.
+func less(a, b Vector) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+	return a.Z < b.Z
+}
+
+// unionFind represents disjoint sets of the indices 0 to n-1.
+type unionFind []int
+
+// newUnionFind returns a 'unionFind' with every index in its own set.
+func newUnionFind(n int) unionFind {
+	u := make(unionFind, n)
+	for i := range u {
+		u[i] = i
+	}
+	return u
+}
+
+// find will return the representative index of the set holding i.
+func (u unionFind) find(i int) int {
+	for u[i] != i {
+		u[i] = u[u[i]]
+		i = u[i]
+	}
+	return i
+}
+
+// union will merge the sets holding a and b and report whether they were distinct.
+func (u unionFind) union(a, b int) bool {
+	ra, rb := u.find(a), u.find(b)
+	if ra == rb {
+		return false
+	}
+	u[rb] = ra
+	return true
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeEdges(t *testing.T) {
+	// Arrange
+	var (
+		cube = unitCube()
+		fin  = Facet{
+			Vertices: []Vector{
+				{X: 0, Y: 0, Z: 0},
+				{X: 1, Y: 0, Z: 0},
+				{X: .5, Y: -1, Z: 0},
+			},
+		}
+	)
+	tcs := map[string]struct {
+		solid       Solid
+		edges       int
+		boundary    int
+		nonManifold int
+		holes       int
+		watertight  bool
+	}{
+		"closed cube": {
+			solid:      cube,
+			edges:      18,
+			watertight: true,
+		},
+		"one missing facet": {
+			solid:    Solid{Facets: cube.Facets[1:]},
+			edges:    18,
+			boundary: 3,
+			holes:    1,
+		},
+		"missing facets on opposite sides": {
+			solid:    Solid{Facets: append(append([]Facet{}, cube.Facets[1:2]...), cube.Facets[3:]...)},
+			edges:    18,
+			boundary: 6,
+			holes:    2,
+		},
+		"fin on a cube edge": {
+			solid:       Solid{Facets: append(append([]Facet{}, cube.Facets...), fin)},
+			edges:       20,
+			boundary:    2,
+			nonManifold: 1,
+			holes:       0,
+		},
+		"empty": {
+			solid: Solid{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			r := tc.solid.AnalyzeEdges()
+
+			// Assert
+			require.Equal(t, tc.edges, r.Edges)
+			require.Len(t, r.Boundary, tc.boundary)
+			require.Len(t, r.NonManifold, tc.nonManifold)
+			require.Equal(t, tc.holes, r.Holes)
+			require.Equal(t, tc.watertight, r.Watertight())
+		})
+	}
+}
+
+func TestAnalyzeEdgesFacets(t *testing.T) {
+	// Arrange
+	s := Solid{Facets: unitCube().Facets[1:]}
+
+	// Act
+	r := s.AnalyzeEdges()
+
+	// Assert
+	for _, e := range r.Boundary {
+		require.Len(t, e.Facets, 1)
+		require.True(t, less(e.A, e.B))
+	}
+	require.Len(t, r.NonManifold, 0)
+}
@@ -0,0 +1,199 @@
+package parser
+
+import "math"
+
+// MillimetersPerInch is the factor to scale a solid modeled in inches to millimeters.
+const MillimetersPerInch = 25.4
+
+// Transform represents an affine transformation as a 4x4 matrix in row major
+// order, applied to points as column vectors with an implicit w of 1.
+type Transform [4][4]float64
+
+// Identity returns the transformation leaving every point in place.
+func Identity() Transform {
+	return Transform{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Translate returns the transformation moving every point by v.
+func Translate(v Vector) Transform {
+	t := Identity()
+	t[0][3], t[1][3], t[2][3] = v.X, v.Y, v.Z
+	return t
+}
+
+// Scale returns the transformation scaling every point from the origin by the factor of each axis.
+func Scale(v Vector) Transform {
+	t := Identity()
+	t[0][0], t[1][1], t[2][2] = v.X, v.Y, v.Z
+	return t
+}
+
+// Rotate returns the transformation rotating every point by angle radians around the
+// axis through the origin, counter clockwise when looking down the axis. The axis does
+// not need to be of unit length, a zero axis returns the identity.
+func Rotate(axis Vector, angle float64) Transform {
+	l := axis.length()
+	if l == 0 {
+		return Identity()
+	}
+
+	var (
+		a    = axis.scale(1 / l)
+		c, s = math.Cos(angle), math.Sin(angle)
+		k    = 1 - c
+	)
+	return Transform{
+		{c + a.X*a.X*k, a.X*a.Y*k - a.Z*s, a.X*a.Z*k + a.Y*s, 0},
+		{a.Y*a.X*k + a.Z*s, c + a.Y*a.Y*k, a.Y*a.Z*k - a.X*s, 0},
+		{a.Z*a.X*k - a.Y*s, a.Z*a.Y*k + a.X*s, c + a.Z*a.Z*k, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Mirror returns the transformation reflecting every point across the plane through the
+// origin with the given normal, which does not need to be of unit length. A zero normal
+// returns the identity.
+func Mirror(normal Vector) Transform {
+	l := normal.length()
+	if l == 0 {
+		return Identity()
+	}
+
+	var (
+		n = normal.scale(1 / l)
+		t = Identity()
+		c = [3]float64{n.X, n.Y, n.Z}
+	)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] -= 2 * c[i] * c[j]
+		}
+	}
+	return t
+}
+
+// Then returns the transformation applying t followed by o.
+func (t Transform) Then(o Transform) Transform {
+	var out Transform
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				out[i][j] += o[i][k] * t[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// Determinant returns the determinant of the linear part of the transformation,
+// which is negative when the transformation mirrors.
+func (t Transform) Determinant() float64 {
+	return t[0][0]*(t[1][1]*t[2][2]-t[1][2]*t[2][1]) -
+		t[0][1]*(t[1][0]*t[2][2]-t[1][2]*t[2][0]) +
+		t[0][2]*(t[1][0]*t[2][1]-t[1][1]*t[2][0])
+}
+
+// Transform will return the point v moved by the transformation.
+func (v Vector) Transform(t Transform) Vector {
+	return Vector{
+		X: t[0][0]*v.X + t[0][1]*v.Y + t[0][2]*v.Z + t[0][3],
+		Y: t[1][0]*v.X + t[1][1]*v.Y + t[1][2]*v.Z + t[1][3],
+		Z: t[2][0]*v.X + t[2][1]*v.Y + t[2][2]*v.Z + t[2][3],
+	}
+}
+
+// transformNormal will return the direction n moved by the inverse transpose of the linear
+// part of the transformation, scaled back to unit length. The inverse transpose is the
+// cofactor matrix divided by the determinant, so only the sign of the determinant is kept.
+func (t Transform) transformNormal(n Vector, det float64) Vector {
+	cof := Vector{
+		X: (t[1][1]*t[2][2]-t[1][2]*t[2][1])*n.X - (t[1][0]*t[2][2]-t[1][2]*t[2][0])*n.Y + (t[1][0]*t[2][1]-t[1][1]*t[2][0])*n.Z,
+		Y: -(t[0][1]*t[2][2]-t[0][2]*t[2][1])*n.X + (t[0][0]*t[2][2]-t[0][2]*t[2][0])*n.Y - (t[0][0]*t[2][1]-t[0][1]*t[2][0])*n.Z,
+		Z: (t[0][1]*t[1][2]-t[0][2]*t[1][1])*n.X - (t[0][0]*t[1][2]-t[0][2]*t[1][0])*n.Y + (t[0][0]*t[1][1]-t[0][1]*t[1][0])*n.Z,
+	}
+	l := cof.length()
+	if l == 0 {
+		return Vector{}
+	}
+	if det < 0 {
+		l = -l
+	}
+	return cof.scale(1 / l)
+}
+
+// Transform will return a copy of the solid with every vertex and stored normal moved by the
+// transformation, reversing the winding of every facet when it mirrors.
+func (s Solid) Transform(t Transform) Solid {
+	var (
+		out = Solid{Name: s.Name, Header: s.Header, Facets: make([]Facet, len(s.Facets))}
+		det = t.Determinant()
+	)
+	for i := 0; i < len(s.Facets); i++ {
+		var (
+			f  = s.Facets[i]
+			vs = make([]Vector, len(f.Vertices))
+		)
+		for j, v := range f.Vertices {
+			vs[j] = v.Transform(t)
+		}
+		// A mirrored facet would point inward without reversing its winding.
+		if det < 0 && len(vs) == 3 {
+			vs[1], vs[2] = vs[2], vs[1]
+		}
+
+		g := Facet{Vertices: vs, Attribute: f.Attribute}
+		// Zero normals are kept and a solid collapsed onto a plane has no inverse transpose.
+		switch {
+		case f.Normal == (Vector{}):
+		case det == 0:
+			g.Normal = g.GeometricNormal()
+		default:
+			g.Normal = t.transformNormal(f.Normal, det)
+		}
+		out.Facets[i] = g
+	}
+	return out
+}
+
+// Translate will return a copy of the solid moved by v.
+func (s Solid) Translate(v Vector) Solid {
+	return s.Transform(Translate(v))
+}
+
+// Scale will return a copy of the solid scaled from the origin by the factor of each axis.
+func (s Solid) Scale(v Vector) Solid {
+	return s.Transform(Scale(v))
+}
+
+// Rotate will return a copy of the solid rotated by angle radians around the axis through the origin.
+func (s Solid) Rotate(axis Vector, angle float64) Solid {
+	return s.Transform(Rotate(axis, angle))
+}
+
+// Mirror will return a copy of the solid reflected across the plane through the origin with the given normal.
+func (s Solid) Mirror(normal Vector) Solid {
+	return s.Transform(Mirror(normal))
+}
+
+// CenterOnOrigin will return a copy of the solid moved so the center of its bounding box is the origin.
+func (s Solid) CenterOnOrigin() Solid {
+	if len(s.Facets) == 0 {
+		return s.Transform(Identity())
+	}
+	lo, hi := s.BoundingBox()
+	return s.Translate(Vector{}.sub(lo.add(hi).scale(0.5)))
+}
+
+// DropToZero will return a copy of the solid moved along Z so its lowest vertex rests on z=0.
+func (s Solid) DropToZero() Solid {
+	if len(s.Facets) == 0 {
+		return s.Transform(Identity())
+	}
+	lo, _ := s.BoundingBox()
+	return s.Translate(Vector{Z: -lo.Z})
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireVectorInDelta asserts every coordinate of out is within delta of expected.
+func requireVectorInDelta(t *testing.T, expected, out Vector, delta float64) {
+	t.Helper()
+	require.InDelta(t, expected.X, out.X, delta, "X of %+v", out)
+	require.InDelta(t, expected.Y, out.Y, delta, "Y of %+v", out)
+	require.InDelta(t, expected.Z, out.Z, delta, "Z of %+v", out)
+}
+
+func TestVectorTransform(t *testing.T) {
+	// Arrange
+	var (
+		v   = Vector{X: 1, Y: 2, Z: 3}
+		tcs = map[string]struct {
+			transform Transform
+			expected  Vector
+		}{
+			"identity": {
+				transform: Identity(),
+				expected:  Vector{X: 1, Y: 2, Z: 3},
+			},
+			"translate": {
+				transform: Translate(Vector{X: -1, Y: 1, Z: 10}),
+				expected:  Vector{X: 0, Y: 3, Z: 13},
+			},
+			"scale": {
+				transform: Scale(Vector{X: 2, Y: 3, Z: -1}),
+				expected:  Vector{X: 2, Y: 6, Z: -3},
+			},
+			"rotate z": {
+				transform: Rotate(Vector{Z: 1}, math.Pi/2),
+				expected:  Vector{X: -2, Y: 1, Z: 3},
+			},
+			"rotate diagonal": {
+				transform: Rotate(Vector{X: 1, Y: 1, Z: 1}, 2*math.Pi/3),
+				expected:  Vector{X: 3, Y: 1, Z: 2},
+			},
+			"mirror x": {
+				transform: Mirror(Vector{X: 2}),
+				expected:  Vector{X: -1, Y: 2, Z: 3},
+			},
+			"mirror diagonal": {
+				transform: Mirror(Vector{X: 1, Y: -1}),
+				expected:  Vector{X: 2, Y: 1, Z: 3},
+			},
+			"then": {
+				transform: Translate(Vector{X: 1}).Then(Scale(Vector{X: 2, Y: 2, Z: 2})),
+				expected:  Vector{X: 4, Y: 4, Z: 6},
+			},
+		}
+	)
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := v.Transform(tc.transform)
+
+			// Assert
+			requireVectorInDelta(t, tc.expected, out, 1e-12)
+		})
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	// Assert
+	require.Equal(t, 1.0, Identity().Determinant())
+	require.Equal(t, 24.0, Scale(Vector{X: 2, Y: 3, Z: 4}).Determinant())
+	require.InDelta(t, -1, Mirror(Vector{X: 1, Y: 2, Z: 3}).Determinant(), 1e-12)
+	require.InDelta(t, 1, Rotate(Vector{X: 1, Y: 2, Z: 3}, 0.7).Determinant(), 1e-12)
+}
+
+func TestSolidTransform(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		transform Transform
+		volume    float64
+	}{
+		"translate": {
+			transform: Translate(Vector{X: 1, Y: -2, Z: 3}),
+			volume:    1,
+		},
+		"rotate": {
+			transform: Rotate(Vector{X: 1, Y: 2, Z: 3}, 0.7),
+			volume:    1,
+		},
+		"inches to millimeters": {
+			transform: Scale(Vector{X: MillimetersPerInch, Y: MillimetersPerInch, Z: MillimetersPerInch}),
+			volume:    math.Pow(MillimetersPerInch, 3),
+		},
+		"non uniform scale": {
+			transform: Scale(Vector{X: 1, Y: 2, Z: 5}),
+			volume:    10,
+		},
+		"mirror": {
+			transform: Mirror(Vector{X: 1}),
+			volume:    1,
+		},
+		"negative scale": {
+			transform: Scale(Vector{X: -1, Y: 2, Z: 1}),
+			volume:    2,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			out := unitCube().Transform(tc.transform)
+
+			// Assert
+			volume, ok := out.Volume()
+			require.True(t, ok)
+			require.InDelta(t, tc.volume, volume, 1e-9)
+			require.Empty(t, out.CheckNormals(NormalOptions{}))
+		})
+	}
+}
+
+func TestSolidTransformZeroNormal(t *testing.T) {
+	// Arrange
+	s := Solid{Facets: []Facet{{Vertices: []Vector{{X: 0}, {X: 1}, {Y: 1}}, Attribute: 3}}}
+
+	// Act
+	out := s.Rotate(Vector{Z: 1}, 1)
+
+	// Assert
+	require.Equal(t, Vector{}, out.Facets[0].Normal)
+	require.Equal(t, uint16(3), out.Facets[0].Attribute)
+	require.Equal(t, Vector{X: 0}, s.Facets[0].Vertices[0])
+}
+
+func TestCenterOnOrigin(t *testing.T) {
+	// Arrange
+	s := unitCube().Scale(Vector{X: 2, Y: 4, Z: 6}).Translate(Vector{X: 10, Y: 10, Z: 10})
+
+	// Act
+	out := s.CenterOnOrigin()
+
+	// Assert
+	outMin, outMax := out.BoundingBox()
+	requireVectorInDelta(t, Vector{X: -1, Y: -2, Z: -3}, outMin, 1e-12)
+	requireVectorInDelta(t, Vector{X: 1, Y: 2, Z: 3}, outMax, 1e-12)
+}
+
+func TestDropToZero(t *testing.T) {
+	// Arrange
+	s := unitCube().Translate(Vector{X: 3, Y: 4, Z: -7.5})
+
+	// Act
+	out := s.DropToZero()
+
+	// Assert
+	outMin, outMax := out.BoundingBox()
+	requireVectorInDelta(t, Vector{X: 3, Y: 4, Z: 0}, outMin, 1e-12)
+	requireVectorInDelta(t, Vector{X: 4, Y: 5, Z: 1}, outMax, 1e-12)
+	require.Empty(t, Solid{}.DropToZero().Facets)
+}
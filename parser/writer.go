@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultPrecision is the number of digits written after the decimal point by encoders
+// created through 'NewEncoder', -1 writing the fewest digits that read back exactly.
+const DefaultPrecision = -1
+
+// Encoder represents behavior shared by the ASCII and binary encoders.
+type Encoder interface {
+	Encode(s Solid) error
+}
+
+// NewEncoder returns an 'Encoder' writing the given format to w.
+func NewEncoder(w io.Writer, f Format) Encoder {
+	if f == Binary {
+		return NewBinaryEncoder(w)
+	}
+	return NewASCIIEncoder(w, DefaultPrecision)
+}
+
+// ASCIIEncoder represents our object for writing a 'Solid' as an ASCII STL file.
+type ASCIIEncoder struct {
+	w         *bufio.Writer
+	precision int
+}
+
+// NewASCIIEncoder returns a pointer to an 'ASCIIEncoder' writing floating point
+// values with the given number of digits after the decimal point. A precision of -1
+// writes the fewest digits needed to represent each value, with an exponent if shorter.
+func NewASCIIEncoder(w io.Writer, precision int) *ASCIIEncoder {
+	return &ASCIIEncoder{
+		w:         bufio.NewWriter(w),
+		precision: precision,
+	}
+}
+
+// Encode will write the solid in the form accepted by 'Parser.Parse',
+// a 'solid name' line, one 'facet normal' through 'endfacet' block per facet
+// and a closing 'endsolid name' line. Facets holding 'NaN' or infinite values
+// are rejected as the parser only reads them back with 'WithNonFinite'.
+func (e *ASCIIEncoder) Encode(s Solid) error {
+	e.writeLine("solid", s.Name)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			return errors.Errorf("encode ascii: facet [%d] has [%d] vertices, expected 3", i, len(f.Vertices))
+		}
+		for _, v := range append([]Vector{f.Normal}, f.Vertices...) {
+			if !v.isFinite() {
+				return errors.Errorf("encode ascii: facet [%d] has a non finite value [%v]", i, v)
+			}
+		}
+
+		e.writeLine("  facet normal", e.formatVector(f.Normal))
+		e.writeLine("    outer loop", "")
+		for _, v := range f.Vertices {
+			e.writeLine("      vertex", e.formatVector(v))
+		}
+		e.writeLine("    endloop", "")
+		e.writeLine("  endfacet", "")
+	}
+	e.writeLine("endsolid", s.Name)
+
+	if err := e.w.Flush(); err != nil {
+		return errors.WithMessage(err, "encode ascii: unable to write solid")
+	}
+	return nil
+}
+
+// writeLine will write the keyword followed by an optional value and a newline.
+// Write errors are deferred to the final flush of the buffered writer.
+func (e *ASCIIEncoder) writeLine(keyword, value string) {
+	_, _ = e.w.WriteString(keyword)
+	if value != "" {
+		_ = e.w.WriteByte(' ')
+		_, _ = e.w.WriteString(value)
+	}
+	_ = e.w.WriteByte('\n')
+}
+
+// formatVector will format a vector as its space separated X, Y and Z values.
+func (e *ASCIIEncoder) formatVector(v Vector) string {
+	b := make([]byte, 0, 48)
+	b = e.appendFloat(b, v.X)
+	b = append(b, ' ')
+	b = e.appendFloat(b, v.Y)
+	b = append(b, ' ')
+	b = e.appendFloat(b, v.Z)
+	return string(b)
+}
+
+func (e *ASCIIEncoder) appendFloat(b []byte, f float64) []byte {
+	// Avoid writing negative zero as '-0'.
+	if f == 0 {
+		f = 0
+	}
+	// Fixed notation with the fewest digits would spell out every leading zero of tiny values.
+	if e.precision < 0 {
+		return strconv.AppendFloat(b, f, 'g', -1, 64)
+	}
+	return strconv.AppendFloat(b, f, 'f', e.precision, 64)
+}
+
+// BinaryEncoder represents our object for writing a 'Solid' as a binary STL file.
+type BinaryEncoder struct {
+	w *bufio.Writer
+}
+
+// NewBinaryEncoder returns a pointer to a 'BinaryEncoder'.
+func NewBinaryEncoder(w io.Writer) *BinaryEncoder {
+	return &BinaryEncoder{
+		w: bufio.NewWriter(w),
+	}
+}
+
+// Encode will write the 80 byte header, the triangle count and a 50 byte
+// record per facet. The header of the solid is reused when present,
+// otherwise the name of the solid is written in its place.
+func (e *BinaryEncoder) Encode(s Solid) error {
+	if uint64(len(s.Facets)) > math.MaxUint32 {
+		return errors.Errorf("encode binary: [%d] facets exceeds the maximum triangle count", len(s.Facets))
+	}
+
+	header := make([]byte, binaryHeaderSize)
+	if len(s.Header) > 0 {
+		copy(header, s.Header)
+	} else {
+		copy(header, s.Name)
+	}
+	_, _ = e.w.Write(header)
+
+	count := make([]byte, binaryCountSize)
+	binary.LittleEndian.PutUint32(count, uint32(len(s.Facets)))
+	_, _ = e.w.Write(count)
+
+	record := make([]byte, binaryFacetSize)
+	for i := 0; i < len(s.Facets); i++ {
+		f := s.Facets[i]
+		if len(f.Vertices) != 3 {
+			return errors.Errorf("encode binary: facet [%d] has [%d] vertices, expected 3", i, len(f.Vertices))
+		}
+		encodeFacet(record, f)
+		if _, err := e.w.Write(record); err != nil {
+			return errors.WithMessage(err, "encode binary: unable to write facet")
+		}
+	}
+
+	if err := e.w.Flush(); err != nil {
+		return errors.WithMessage(err, "encode binary: unable to write solid")
+	}
+	return nil
+}
+
+// encodeFacet will encode a facet into a 50 byte record, the inverse of 'decodeFacet'.
+func encodeFacet(b []byte, f Facet) {
+	encodeVector(b[0:12], f.Normal)
+	for i := 0; i < 3; i++ {
+		offset := 12 + i*12
+		encodeVector(b[offset:offset+12], f.Vertices[i])
+	}
+	binary.LittleEndian.PutUint16(b[48:50], f.Attribute)
+}
+
+// encodeVector will encode a 'Vector' as three little endian float32 values.
+func encodeVector(b []byte, v Vector) {
+	binary.LittleEndian.PutUint32(b[0:4], math.Float32bits(float32(v.X)))
+	binary.LittleEndian.PutUint32(b[4:8], math.Float32bits(float32(v.Y)))
+	binary.LittleEndian.PutUint32(b[8:12], math.Float32bits(float32(v.Z)))
+}
@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var writerFixture = Solid{
+	Name: "foo",
+	Facets: []Facet{
+		{
+			Normal: Vector{X: 0, Y: 0, Z: 1},
+			Vertices: []Vector{
+				{X: 0, Y: 0, Z: 0},
+				{X: 1.5, Y: 0, Z: 0},
+				{X: 1, Y: 1.25, Z: 0},
+			},
+		},
+		{
+			Normal: Vector{X: 0, Y: 1, Z: 0},
+			Vertices: []Vector{
+				{X: 0, Y: 0, Z: 0},
+				{X: 0, Y: 0, Z: 2},
+				{X: 3, Y: 0, Z: 0},
+			},
+		},
+	},
+}
+
+func TestASCIIEncode(t *testing.T) {
+	// Arrange
+	var (
+		buf      = new(bytes.Buffer)
+		e        = NewASCIIEncoder(buf, 2)
+		expected = `solid foo
+  facet normal 0.00 0.00 1.00
+    outer loop
+      vertex 0.00 0.00 0.00
+      vertex 1.50 0.00 0.00
+      vertex 1.00 1.25 0.00
+    endloop
+  endfacet
+  facet normal 0.00 1.00 0.00
+    outer loop
+      vertex 0.00 0.00 0.00
+      vertex 0.00 0.00 2.00
+      vertex 3.00 0.00 0.00
+    endloop
+  endfacet
+endsolid foo
+`
+	)
+
+	// Act
+	err := e.Encode(writerFixture)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		format Format
+		solid  Solid
+	}{
+		"ascii":  {format: ASCII, solid: writerFixture},
+		"binary": {format: Binary, solid: writerFixture},
+		"ascii small and large values": {
+			format: ASCII,
+			solid: Solid{
+				Name: "foo",
+				Facets: []Facet{
+					{
+						Normal:   Vector{X: 0.1, Y: -1e-7, Z: 1},
+						Vertices: []Vector{{X: 4e-7, Y: 1.0 / 3, Z: 0}, {X: 1e21, Y: -2.5e-300, Z: 0}, {X: 123456.789, Y: 1, Z: 1}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			// Act
+			err := NewEncoder(buf, tc.format).Encode(tc.solid)
+			require.NoError(t, err)
+			d, format, err := Open(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			s, err := d.Parse()
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tc.format, format)
+			require.Equal(t, tc.solid.Name, s.Name)
+			require.Equal(t, tc.solid.Facets, s.Facets)
+		})
+	}
+}
+
+func TestEncodeInvalidFacet(t *testing.T) {
+	// Arrange
+	s := Solid{
+		Name: "foo",
+		Facets: []Facet{
+			{Vertices: []Vector{{X: 1}}},
+		},
+	}
+
+	for _, f := range []Format{ASCII, Binary} {
+		// Act
+		err := NewEncoder(new(bytes.Buffer), f).Encode(s)
+
+		// Assert
+		require.Error(t, err)
+	}
+}
+
+func TestASCIIEncodeNonFinite(t *testing.T) {
+	// Arrange
+	tcs := map[string]struct {
+		facet Facet
+	}{
+		"nan normal": {
+			facet: Facet{Normal: Vector{X: math.NaN()}, Vertices: make([]Vector, 3)},
+		},
+		"infinite vertex": {
+			facet: Facet{Vertices: []Vector{{}, {Y: math.Inf(-1)}, {}}},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := NewASCIIEncoder(new(bytes.Buffer), -1).Encode(Solid{Facets: []Facet{tc.facet}})
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}